@@ -2,23 +2,148 @@ package clickmeeting
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-const clickMeetingURL = "https://api.clickmeeting.com/v1/"
+const (
+	clickMeetingURL  = "https://api.clickmeeting.com/v1/"
+	defaultUserAgent = "clickmeeting.go"
+	defaultTimeout   = 30 * time.Second
+)
 
 type api struct {
-	apiKey string
+	apiKey    string
+	baseURL   string
+	userAgent string
 
 	client *http.Client
+	// streams sends downloads and uploads, it has no Timeout as that would cut off long transfers.
+	streams *http.Client
+	retry   RetryPolicy
+	limiter *rateLimiter
+	logger  Logger
+	regions []string
 }
 
+// NewAPI returns a Client using default settings.
 func NewAPI(apiKey string) Client {
-	return &api{apiKey: apiKey, client: &http.Client{}}
+	return NewAPIWithOptions(apiKey)
+}
+
+// NewAPIWithOptions returns a Client configured with given options.
+func NewAPIWithOptions(apiKey string, opts ...ClientOption) Client {
+	cfg := clientConfig{
+		baseURL:   clickMeetingURL,
+		userAgent: defaultUserAgent,
+		retry:     DefaultRetryPolicy,
+		logger:    noopLogger{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var client http.Client
+	if cfg.client != nil {
+		client = *cfg.client
+	} else {
+		client.Timeout = defaultTimeout
+	}
+	if cfg.transport != nil {
+		client.Transport = cfg.transport
+	}
+	if cfg.timeout != 0 {
+		client.Timeout = cfg.timeout
+	}
+	client.CheckRedirect = stripKeyOnRedirect(client.CheckRedirect)
+	regions := make([]string, 0, len(cfg.regions))
+	for _, baseURL := range cfg.regions {
+		regions = append(regions, strings.TrimSuffix(baseURL, "/")+"/")
+	}
+	streams := client
+	streams.Timeout = 0
+
+	return &api{
+		apiKey:    apiKey,
+		baseURL:   strings.TrimSuffix(cfg.baseURL, "/") + "/",
+		userAgent: cfg.userAgent,
+		client:    &client,
+		streams:   &streams,
+		retry:     cfg.retry,
+		limiter:   cfg.limiter,
+		logger:    cfg.logger,
+		regions:   regions,
+	}
+}
+
+type clientConfig struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+	transport http.RoundTripper
+	timeout   time.Duration
+	retry     RetryPolicy
+	limiter   *rateLimiter
+	logger    Logger
+	regions   []string
+}
+
+type ClientOption func(cfg *clientConfig)
+
+// WithBaseURL sets address of the API, e.g. to point the client at a local stand-in server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets http.Client used to send requests. The client is copied,
+// so WithTimeout and WithRoundTripper do not modify the one passed in.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.client = client
+	}
+}
+
+// WithRoundTripper sets transport used to send requests, e.g. to configure proxy or TLS.
+func WithRoundTripper(transport http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets time limit for a single request. Defaults to 30 seconds.
+// Downloads and uploads are not limited, as they can take much longer, use ctx to bound them.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = userAgent
+	}
+}
+
+// WithRegions sets addresses of the API in other regions. Ping tries them when the api key is rejected,
+// to tell a key of an account in another region apart from an invalid one.
+func WithRegions(baseURLs ...string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.regions = baseURLs
+	}
 }
 
 type encoder interface {
@@ -26,69 +151,271 @@ type encoder interface {
 }
 
 func (api *api) send(req *http.Request, holder interface{}) error {
-	req.Header.Add("X-Api-Key", api.apiKey)
+	return api.sendWith(api.client, req, holder)
+}
 
-	resp, err := api.client.Do(req)
+func (api *api) sendWith(client *http.Client, req *http.Request, holder interface{}) error {
+	resp, err := api.do(client, req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	//d, _ := ioutil.ReadAll(resp.Body)
-	fmt.Println(resp.Status)
-	//fmt.Println(string(d))
+	return json.NewDecoder(resp.Body).Decode(holder)
+}
+
+// stripKeyOnRedirect wraps check so that the api key is not sent to other hosts when following
+// redirects, e.g. from download endpoints to storage. http.Client only drops Authorization and Cookie.
+func stripKeyOnRedirect(check func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host {
+			req.Header.Del("X-Api-Key")
+		}
+		if check != nil {
+			return check(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// do sends req with client, retrying it according to the retry policy, and returns successful response.
+func (api *api) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	// Download links might point outside the API, which must not learn the key.
+	if strings.HasPrefix(req.URL.String(), api.baseURL) {
+		req.Header.Set("X-Api-Key", api.apiKey)
+	}
+	req.Header.Set("User-Agent", api.userAgent)
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		if err := api.limiter.wait(ctx); err != nil {
+			// client.Do closes the body, here it has to be done by hand to release streaming uploads.
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		entry := RequestLog{
+			Method:   req.Method,
+			Path:     req.URL.Path,
+			Duration: time.Since(start),
+			Attempt:  attempt,
+			Header:   redactHeader(req.Header),
+			Err:      err,
+		}
+		if resp != nil {
+			entry.Status = resp.StatusCode
+		}
+		api.logger.LogRequest(ctx, entry)
+
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("failed to send request: %w", ctxErr)
+			}
+			if api.retry.canRetry(req, attempt) {
+				if err := sleep(ctx, api.retry.backoff(attempt)); err != nil {
+					return nil, fmt.Errorf("failed to send request: %w", err)
+				}
+				continue
+			}
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
 
-	dec := json.NewDecoder(resp.Body)
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		var apiErr APIError
-		decErr := dec.Decode(&apiErr)
-		if decErr != nil {
-			return decErr
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated {
+			return resp, nil
 		}
-		return apiErr
+
+		if retryableStatus(resp.StatusCode) && api.retry.canRetry(req, attempt) {
+			delay, ok := retryAfter(resp)
+			if !ok {
+				delay = api.retry.backoff(attempt)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if err := sleep(ctx, delay); err != nil {
+				return nil, fmt.Errorf("failed to send request: %w", err)
+			}
+			continue
+		}
+
+		defer resp.Body.Close()
+		return nil, decodeError(resp)
 	}
-	return dec.Decode(holder)
 }
-func (api *api) sendGet(path string, data encoder, holder interface{}) error {
-	req, err := http.NewRequest(http.MethodGet, api.getURL(path)+"?"+data.Encode(), nil)
+func (api *api) sendGet(ctx context.Context, path string, data encoder, holder interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.getURL(path)+"?"+data.Encode(), nil)
 	if err != nil {
 		return fmt.Errorf("failed to create new request: %w", err)
 	}
 	return api.send(req, holder)
 }
-func (api *api) sendPost(path string, data encoder, holder interface{}) error {
-	req, err := http.NewRequest(http.MethodPost, api.getURL(path), bytes.NewBufferString(data.Encode()))
+func (api *api) sendPost(ctx context.Context, path string, data encoder, holder interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api.getURL(path), bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create new request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	return api.send(req, holder)
 }
-func (api *api) sendPut(path string, data encoder, holder interface{}) error {
-	req, err := http.NewRequest(http.MethodPut, api.getURL(path), bytes.NewBufferString(data.Encode()))
+func (api *api) sendPut(ctx context.Context, path string, data encoder, holder interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, api.getURL(path), bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create new request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	return api.send(req, holder)
 }
-func (api *api) sendDelete(path string, data encoder, holder interface{}) error {
-	req, err := http.NewRequest(http.MethodDelete, api.getURL(path), bytes.NewBufferString(data.Encode()))
+func (api *api) sendDelete(ctx context.Context, path string, data encoder, holder interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, api.getURL(path), bytes.NewBufferString(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create new request: %w", err)
 	}
 	return api.send(req, holder)
 }
 
+// sendMultipart uploads content of r as file field of a multipart form. The body is streamed,
+// so the request is never retried.
+func (api *api) sendMultipart(ctx context.Context, path string, field string, filename string, r io.Reader, progress ProgressFunc, holder interface{}) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile(field, filename)
+		if err == nil {
+			_, err = io.Copy(part, &progressReader{r: r, progress: progress})
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api.getURL(path), pr)
+	if err != nil {
+		pr.Close()
+		return fmt.Errorf("failed to create new request: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return api.sendWith(api.streams, req, holder)
+}
+
+// ProgressFunc is called with the total number of bytes transferred so far.
+type ProgressFunc func(transferred int64)
+
+type progressReader struct {
+	r        io.Reader
+	progress ProgressFunc
+	n        int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.n += int64(n)
+	if n > 0 && p.progress != nil {
+		p.progress(p.n)
+	}
+	return n, err
+}
+
+// download streams body of the resource at rawURL into w.
+func (api *api) download(ctx context.Context, rawURL string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create new request: %w", err)
+	}
+	resp, err := api.do(api.streams, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download %s: %w", req.URL.Path, err)
+	}
+	return n, nil
+}
+
 func (api *api) getURL(path string) string {
-	return fmt.Sprintf("%s%s.json", clickMeetingURL, path)
+	return fmt.Sprintf("%s%s.json", api.baseURL, path)
 }
 
-func (api *api) ListRooms(status RoomStatus) ([]Room, error) {
+func (api *api) ListRooms(ctx context.Context, status RoomStatus) ([]Room, error) {
 	var rooms []Room
-	err := api.sendGet("conferences/"+string(status), url.Values{}, &rooms)
+	err := api.sendGet(ctx, "conferences/"+string(status), url.Values{}, &rooms)
 	return rooms, err
 }
 
-func (api *api) CreateRoom(newRoom NewRoom, opts ...CreateRoomOption) (Room, error) {
+func (api *api) GetRoom(ctx context.Context, roomID int) (Room, error) {
+	var resp struct {
+		Room Room `json:"conference"`
+	}
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d", roomID), url.Values{}, &resp)
+	return resp.Room, err
+}
+
+// FindRoomBySlug looks up active and inactive rooms for the one with given slug.
+// Room url, as found in Room.RoomURL, is accepted as well.
+func (api *api) FindRoomBySlug(ctx context.Context, slug string) (Room, error) {
+	if strings.Contains(slug, "/") {
+		var err error
+		slug, err = roomSlugFromURL(slug)
+		if err != nil {
+			return Room{}, err
+		}
+	}
+
+	for _, status := range []RoomStatus{ActiveRoom, InactiveRoom} {
+		rooms, err := api.ListRooms(ctx, status)
+		if err != nil {
+			return Room{}, err
+		}
+		for _, room := range rooms {
+			if room.Slug == slug {
+				return room, nil
+			}
+		}
+	}
+	return Room{}, fmt.Errorf("room %q: %w", slug, ErrNotFound)
+}
+
+// ParseRoomURL resolves room url, as found in Room.RoomURL or pasted from a browser, to the room.
+func (api *api) ParseRoomURL(ctx context.Context, roomURL string) (Room, error) {
+	slug, err := roomSlugFromURL(roomURL)
+	if err != nil {
+		return Room{}, err
+	}
+	return api.FindRoomBySlug(ctx, slug)
+}
+
+// roomSlugFromURL returns slug of the room from its url, e.g. "my-room" from "https://account.clickmeeting.com/my-room".
+func roomSlugFromURL(roomURL string) (string, error) {
+	u, err := url.Parse(roomURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse room url: %w", err)
+	}
+	slug := path.Base(strings.TrimSuffix(u.Path, "/"))
+	if slug == "." || slug == "/" || slug == "" {
+		return "", fmt.Errorf("room url %q has no room name", roomURL)
+	}
+	return slug, nil
+}
+
+func (api *api) CreateRoom(ctx context.Context, newRoom NewRoom, opts ...CreateRoomOption) (Room, error) {
 	v := url.Values{}
 	v.Add("name", newRoom.Name)
 	v.Add("room_type", newRoom.RoomType.String())
@@ -101,56 +428,173 @@ func (api *api) CreateRoom(newRoom NewRoom, opts ...CreateRoomOption) (Room, err
 	for _, opt := range opts {
 		opt(v)
 	}
+	if err := checkStartsAt(v); err != nil {
+		return Room{}, err
+	}
 	var resp struct {
 		Room Room `json:"room"`
 	}
-	err := api.sendPost("conferences", v, &resp)
+	err := api.sendPost(ctx, "conferences", v, &resp)
 
 	return resp.Room, err
 }
 
-func (api *api) UpdateRoom(roomID int, opts ...UpdateRoomOption) (Room, error) {
+func (api *api) UpdateRoom(ctx context.Context, roomID int, opts ...UpdateRoomOption) (Room, error) {
 	v := url.Values{}
 	for _, opt := range opts {
 		opt(v)
 	}
+	if err := checkStartsAt(v); err != nil {
+		return Room{}, err
+	}
 	var resp struct {
 		Room Room `json:"conference"`
 	}
-	err := api.sendPut(fmt.Sprintf("conferences/%d", roomID), v, &resp)
+	err := api.sendPut(ctx, fmt.Sprintf("conferences/%d", roomID), v, &resp)
 
 	return resp.Room, err
 }
 
-func (api *api) DeleteRoom(roomID int) error {
+// ModifyRoomSettings fetches current settings of the room, lets modify change them
+// and sends only the settings that changed. The room is not updated if nothing changed.
+func (api *api) ModifyRoomSettings(ctx context.Context, roomID int, modify func(s *RoomSettings)) (Room, error) {
+	room, err := api.GetRoom(ctx, roomID)
+	if err != nil {
+		return Room{}, err
+	}
+
+	settings := room.Settings
+	modify(&settings)
+	patch := room.Settings.Diff(settings)
+	if patch.IsEmpty() {
+		return room, nil
+	}
+	return api.UpdateRoom(ctx, roomID, SetRoomSettingsPatch(patch))
+}
+
+func (api *api) DeleteRoom(ctx context.Context, roomID int) error {
 	var resp struct {
 		Result string `json:"result"`
 	}
-	err := api.sendDelete(fmt.Sprintf("conferences/%d", roomID), url.Values{}, &resp)
+	err := api.sendDelete(ctx, fmt.Sprintf("conferences/%d", roomID), url.Values{}, &resp)
 	return err
 }
 
-func (api *api) GetSessions(roomID int) ([]SessionSummary, error) {
-	panic("implement me")
+func (api *api) GetSessions(ctx context.Context, roomID int) ([]SessionSummary, error) {
+	var sessions []SessionSummary
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/sessions", roomID), url.Values{}, &sessions)
+	return sessions, err
 }
 
-func (api *api) GetSession(roomID int, sessionID int) (Session, error) {
-	panic("implement me")
+func (api *api) GetSession(ctx context.Context, roomID int, sessionID int) (Session, error) {
+	var session Session
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/sessions/%d", roomID, sessionID), url.Values{}, &session)
+	return session, err
 }
 
-func (api *api) GenerateAccessTokens(roomID int, howMany int) ([]AccessToken, error) {
-	panic("implement me")
+// GeneratePDFReport starts generating report of the session in given language.
+// The report is ready once returned Progress reaches 100.
+func (api *api) GeneratePDFReport(ctx context.Context, roomID int, sessionID int, lang string) (PDFSummary, error) {
+	var resp struct {
+		Status   string `json:"status"`
+		Progress int    `json:"progress"`
+		URL      string `json:"url"`
+	}
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/sessions/%d/generate-pdf/%s", roomID, sessionID, lang), url.Values{}, &resp)
+	return PDFSummary{URL: resp.URL, Progress: resp.Progress}, err
+}
+
+const defaultPDFPollInterval = 5 * time.Second
+
+// WaitForPDFReport polls the session every interval until report in given language is generated,
+// then writes it to w. Zero interval polls every 5 seconds.
+func (api *api) WaitForPDFReport(ctx context.Context, roomID int, sessionID int, lang string, interval time.Duration, w io.Writer) (int64, error) {
+	if interval <= 0 {
+		interval = defaultPDFPollInterval
+	}
+
+	for {
+		session, err := api.GetSession(ctx, roomID, sessionID)
+		if err != nil {
+			return 0, err
+		}
+		if pdf, ok := session.PDF[lang]; ok && pdf.Progress >= 100 && pdf.URL != "" {
+			return api.download(ctx, pdf.URL, w)
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return 0, fmt.Errorf("failed to wait for pdf report: %w", err)
+		}
+	}
+}
+
+// maxTokensPerRequest is the largest how_many value accepted by the tokens endpoint.
+const maxTokensPerRequest = 1000
+
+func (api *api) GenerateAccessTokens(ctx context.Context, roomID int, howMany int) ([]AccessToken, error) {
+	if howMany < 0 {
+		return nil, fmt.Errorf("invalid number of tokens: %d", howMany)
+	}
+
+	// Zero tokens requested results in an empty slice without calling the API.
+	size := howMany
+	if size > maxTokensPerRequest {
+		size = maxTokensPerRequest
+	}
+	tokens := make([]AccessToken, 0, size)
+	for remaining := howMany; remaining > 0; remaining -= maxTokensPerRequest {
+		n := remaining
+		if n > maxTokensPerRequest {
+			n = maxTokensPerRequest
+		}
+		v := url.Values{}
+		v.Add("how_many", strconv.Itoa(n))
+
+		var resp struct {
+			AccessTokens []AccessToken `json:"access_tokens"`
+		}
+		if err := api.sendPost(ctx, fmt.Sprintf("conferences/%d/tokens", roomID), v, &resp); err != nil {
+			return tokens, err
+		}
+		tokens = append(tokens, resp.AccessTokens...)
+	}
+	return tokens, nil
 }
 
-func (api *api) GetAccessTokens(roomID int) ([]AccessToken, error) {
-	panic("implement me")
+func (api *api) GetAccessTokens(ctx context.Context, roomID int) ([]AccessToken, error) {
+	var resp struct {
+		AccessTokens []AccessToken `json:"access_tokens"`
+	}
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/tokens", roomID), url.Values{}, &resp)
+	return resp.AccessTokens, err
+}
+
+func (api *api) AutoLoginHash(ctx context.Context, roomID int, opts ...AutoLoginOption) (string, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	var resp struct {
+		AutoLoginHash string `json:"autologin_hash"`
+	}
+	err := api.sendPost(ctx, fmt.Sprintf("conferences/%d/room/autologin_hash", roomID), v, &resp)
+	return resp.AutoLoginHash, err
 }
 
-func (api *api) AutoLoginHash(roomID int) (string, error) {
-	panic("implement me")
+// AutoLoginURL combines room url with hash returned by AutoLoginHash into a link
+// that logs the user straight into the room.
+func AutoLoginURL(room Room, hash string) (string, error) {
+	u, err := url.Parse(room.RoomURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse room url: %w", err)
+	}
+	q := u.Query()
+	q.Set("l", hash)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
 }
 
-func (api *api) SendInvitation(roomID int, language string, attendees []string, opts ...SendInvitationOption) error {
+func (api *api) SendInvitation(ctx context.Context, roomID int, language string, attendees []string, opts ...SendInvitationOption) error {
 	v := url.Values{
 		"attendees[][email]": attendees,
 	}
@@ -159,16 +603,16 @@ func (api *api) SendInvitation(roomID int, language string, attendees []string,
 	}
 
 	var empty interface{}
-	return api.sendPost(fmt.Sprintf("conferences/%d/invitation/email/%s", roomID, language), v, &empty)
+	return api.sendPost(ctx, fmt.Sprintf("conferences/%d/invitation/email/%s", roomID, language), v, &empty)
 }
 
-func (api *api) GetRegistrations(roomID int, status string) ([]Participant, error) {
+func (api *api) GetRegistrations(ctx context.Context, roomID int, status string) ([]Participant, error) {
 	var participants []Participant
-	err := api.sendGet(fmt.Sprintf("conferences/%d/registrations/%s", roomID, status), url.Values{}, &participants)
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/registrations/%s", roomID, status), url.Values{}, &participants)
 	return participants, err
 }
 
-func (api *api) RegisterParticipant(roomID int, participant NewParticipant, opts ...RegisterParticipantOption) (string, error) {
+func (api *api) RegisterParticipant(ctx context.Context, roomID int, participant NewParticipant, opts ...RegisterParticipantOption) (string, error) {
 	v := url.Values{}
 	v.Add("registration[1]", participant.FirstName)
 	v.Add("registration[2]", participant.LastName)
@@ -182,10 +626,209 @@ func (api *api) RegisterParticipant(roomID int, participant NewParticipant, opts
 		Status string `json:"status"`
 		URL    string `json:"url"`
 	}
-	err := api.sendPost(fmt.Sprintf("conferences/%d/registration", roomID), v, &resp)
+	err := api.sendPost(ctx, fmt.Sprintf("conferences/%d/registration", roomID), v, &resp)
 	return resp.URL, err
 }
 
-func (api *api) GetParticipants(roomID int, sessionID int) ([]Participant, error) {
-	panic("implement me")
+func (api *api) GetParticipants(ctx context.Context, roomID int, sessionID int) ([]SessionParticipant, error) {
+	var participants []SessionParticipant
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/sessions/%d/attendees", roomID, sessionID), url.Values{}, &participants)
+	return participants, err
+}
+
+func (api *api) ListRecordings(ctx context.Context, roomID int) ([]Recording, error) {
+	var recordings []Recording
+	err := api.sendGet(ctx, fmt.Sprintf("conferences/%d/recordings", roomID), url.Values{}, &recordings)
+	return recordings, err
+}
+
+func (api *api) DownloadRecording(ctx context.Context, recording Recording, w io.Writer) (int64, error) {
+	if recording.URL == "" {
+		return 0, fmt.Errorf("recording %d has no url", recording.ID)
+	}
+	return api.download(ctx, recording.URL, w)
+}
+
+func (api *api) DeleteRecording(ctx context.Context, roomID int, recordingID int) error {
+	var resp struct {
+		Result string `json:"result"`
+	}
+	return api.sendDelete(ctx, fmt.Sprintf("conferences/%d/recordings/%d", roomID, recordingID), url.Values{}, &resp)
+}
+
+func (api *api) DeleteAllRecordings(ctx context.Context, roomID int) error {
+	var resp struct {
+		Result string `json:"result"`
+	}
+	return api.sendDelete(ctx, fmt.Sprintf("conferences/%d/recordings", roomID), url.Values{}, &resp)
+}
+
+// UploadFile uploads file to the file library. When roomID is not zero, the file is attached to that room.
+func (api *api) UploadFile(ctx context.Context, roomID int, filename string, r io.Reader, progress ProgressFunc) (File, error) {
+	path := "file-library"
+	if roomID != 0 {
+		path = fmt.Sprintf("file-library/conferences/%d", roomID)
+	}
+
+	var file File
+	err := api.sendMultipart(ctx, path, "uploaded", filename, r, progress, &file)
+	return file, err
+}
+
+func (api *api) ListFiles(ctx context.Context) ([]File, error) {
+	var files []File
+	err := api.sendGet(ctx, "file-library", url.Values{}, &files)
+	return files, err
+}
+
+func (api *api) ListRoomFiles(ctx context.Context, roomID int) ([]File, error) {
+	var files []File
+	err := api.sendGet(ctx, fmt.Sprintf("file-library/conferences/%d", roomID), url.Values{}, &files)
+	return files, err
+}
+
+func (api *api) DownloadFile(ctx context.Context, fileID int, w io.Writer) (int64, error) {
+	return api.download(ctx, fmt.Sprintf("%sfile-library/%d/download", api.baseURL, fileID), w)
+}
+
+func (api *api) DeleteFile(ctx context.Context, fileID int) error {
+	var resp struct {
+		Result string `json:"result"`
+	}
+	return api.sendDelete(ctx, fmt.Sprintf("file-library/%d", fileID), url.Values{}, &resp)
+}
+
+func (api *api) ListChats(ctx context.Context) ([]Chat, error) {
+	var chats []Chat
+	err := api.sendGet(ctx, "chats", url.Values{}, &chats)
+	return chats, err
+}
+
+// GetChat downloads chat archive of the session and parses it into messages.
+// Timestamps of messages are interpreted in loc, usually the time zone of the room, or in UTC when loc is nil.
+func (api *api) GetChat(ctx context.Context, sessionID int, loc *time.Location) ([]ChatMessage, error) {
+	var buf bytes.Buffer
+	if _, err := api.download(ctx, fmt.Sprintf("%schats/%d", api.baseURL, sessionID), &buf); err != nil {
+		return nil, err
+	}
+	return ParseChatArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), loc)
+}
+
+// ListTimeZones returns time zones accepted by the API. Empty country returns all of them,
+// otherwise only ones of the country given as ISO 3166-1 alpha-2 code, e.g. "pl".
+func (api *api) ListTimeZones(ctx context.Context, country string) (TimeZones, error) {
+	path := "time_zone_list"
+	if country != "" {
+		path += "/" + strings.ToLower(country)
+	}
+
+	var zones TimeZones
+	err := api.sendGet(ctx, path, url.Values{}, &zones)
+	return zones, err
+}
+
+func (api *api) ListPhoneGateways(ctx context.Context) ([]PhoneGateway, error) {
+	var gateways []PhoneGateway
+	err := api.sendGet(ctx, "phone_gateways", url.Values{}, &gateways)
+	return gateways, err
+}
+
+func (api *api) ListSkins(ctx context.Context) (Skins, error) {
+	var skins Skins
+	err := api.sendGet(ctx, "conferences/skins", url.Values{}, &skins)
+	return skins, err
+}
+
+func (api *api) AddContact(ctx context.Context, contact Contact) error {
+	v := url.Values{}
+	v.Add("email", contact.Email)
+	v.Add("firstname", contact.FirstName)
+	v.Add("lastname", contact.LastName)
+	if contact.Phone != "" {
+		v.Add("phone", contact.Phone)
+	}
+	if contact.Company != "" {
+		v.Add("company", contact.Company)
+	}
+
+	var resp struct {
+		Result string `json:"result"`
+	}
+	return api.sendPost(ctx, "contacts", v, &resp)
+}
+
+func (api *api) ListContacts(ctx context.Context) ([]Contact, error) {
+	var contacts []Contact
+	err := api.sendGet(ctx, "contacts", url.Values{}, &contacts)
+	return contacts, err
+}
+
+// ImportContacts adds contacts running at most concurrency requests at once.
+// Results are returned in the same order as contacts.
+func (api *api) ImportContacts(ctx context.Context, contacts []Contact, concurrency int) []ContactResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]ContactResult, len(contacts))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, contact := range contacts {
+		results[i].Contact = contact
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, contact Contact) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].Err = api.AddContact(ctx, contact)
+		}(i, contact)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Ping checks that the API is reachable and accepts the api key. Every failure matches one of
+// ErrUnreachable, ErrInvalidAPIKey or ErrWrongRegion. Key rejected by the API is reported as
+// ErrWrongRegion when the API of one of the regions set with WithRegions accepts it.
+func (api *api) Ping(ctx context.Context) error {
+	err := api.ping(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden):
+		if api.acceptedInOtherRegion(ctx) {
+			return fmt.Errorf("%w: %w", ErrWrongRegion, err)
+		}
+		return fmt.Errorf("%w: %w", ErrInvalidAPIKey, err)
+	}
+	return fmt.Errorf("%w: %w", ErrUnreachable, err)
+}
+
+func (api *api) ping(ctx context.Context) error {
+	var resp struct {
+		Ping string `json:"ping"`
+	}
+	if err := api.sendGet(ctx, "ping", url.Values{}, &resp); err != nil {
+		return err
+	}
+	if resp.Ping != "pong" {
+		return fmt.Errorf("unexpected ping response %q", resp.Ping)
+	}
+	return nil
+}
+
+// acceptedInOtherRegion reports whether the API of any other region accepts the api key.
+func (api *api) acceptedInOtherRegion(ctx context.Context) bool {
+	for _, baseURL := range api.regions {
+		other := *api
+		other.baseURL = baseURL
+		if other.ping(ctx) == nil {
+			return true
+		}
+	}
+	return false
 }
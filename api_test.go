@@ -1,8 +1,19 @@
 package clickmeeting_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,7 +29,7 @@ func Test_RoomAPI(t *testing.T) {
 	var roomID int
 	t.Run("CreateRoom", func(t *testing.T) {
 		is := is.New(t)
-		room, err := api.CreateRoom(
+		room, err := api.CreateRoom(context.Background(),
 			clickmeeting.NewRoom{
 				Name:          "My new sweet room",
 				RoomType:      clickmeeting.Webinar,
@@ -55,12 +66,12 @@ func Test_RoomAPI(t *testing.T) {
 
 	t.Run("UpdateRoom", func(t *testing.T) {
 		is := is.New(t)
-		room, err := api.UpdateRoom(roomID,
+		room, err := api.UpdateRoom(context.Background(), roomID,
 			clickmeeting.SetName("Webinarium"),
 			clickmeeting.SetLobby(false, ""),
 			clickmeeting.SetDuration(4*time.Hour),
 			clickmeeting.SetPermanence(false),
-			clickmeeting.SetStartsAt(time.Now().Add(24*time.Hour)),
+			clickmeeting.SetStartsAt(time.Now().Add(24*time.Hour), "Europe/Warsaw"),
 			clickmeeting.SetRoomType(clickmeeting.Webinar),
 			clickmeeting.SetPassword("qwesdwdrty"),
 			//clickmeeting.SetAccessType(clickmeeting.TokenProtected)
@@ -73,7 +84,7 @@ func Test_RoomAPI(t *testing.T) {
 
 	t.Run("DeleteRoom", func(t *testing.T) {
 		is := is.New(t)
-		err := api.DeleteRoom(roomID)
+		err := api.DeleteRoom(context.Background(), roomID)
 		is.NoErr(err)
 	})
 
@@ -88,7 +99,7 @@ func Test_Participants(t *testing.T) {
 	t.Run("CreateRoom", func(t *testing.T) {
 		is := is.New(t)
 
-		room, err := api.CreateRoom(clickmeeting.NewRoom{
+		room, err := api.CreateRoom(context.Background(), clickmeeting.NewRoom{
 			Name:          "Testing",
 			RoomType:      clickmeeting.Webinar,
 			PermanentRoom: false,
@@ -99,14 +110,14 @@ func Test_Participants(t *testing.T) {
 	})
 	t.Cleanup(func() {
 		if roomID != 0 {
-			api.DeleteRoom(roomID)
+			api.DeleteRoom(context.Background(), roomID)
 		}
 	})
 
 	t.Run("RegisterParticipant", func(t *testing.T) {
 		is := is.New(t)
 
-		attendURL, err := api.RegisterParticipant(roomID, clickmeeting.NewParticipant{
+		attendURL, err := api.RegisterParticipant(context.Background(), roomID, clickmeeting.NewParticipant{
 			FirstName:    "Jon",
 			LastName:     "Doe",
 			EmailAddress: "jon@doe.com",
@@ -118,7 +129,7 @@ func Test_Participants(t *testing.T) {
 	t.Run("ListParticipants", func(t *testing.T) {
 		is := is.New(t)
 
-		people, err := api.GetRegistrations(roomID, "all")
+		people, err := api.GetRegistrations(context.Background(), roomID, "all")
 		is.NoErr(err)
 		is.Equal(len(people), 1)
 		is.Equal(people[0].Email, "jon@doe.com")
@@ -134,7 +145,7 @@ func Test_Invitations(t *testing.T) {
 	t.Run("CreateRoom", func(t *testing.T) {
 		is := is.New(t)
 
-		room, err := api.CreateRoom(clickmeeting.NewRoom{
+		room, err := api.CreateRoom(context.Background(), clickmeeting.NewRoom{
 			Name:          "Testing",
 			RoomType:      clickmeeting.Webinar,
 			PermanentRoom: false,
@@ -145,27 +156,879 @@ func Test_Invitations(t *testing.T) {
 	})
 	t.Cleanup(func() {
 		if roomID != 0 {
-			api.DeleteRoom(roomID)
+			api.DeleteRoom(context.Background(), roomID)
 		}
 	})
 
 	t.Run("SendInvitation", func(t *testing.T) {
 		is := is.New(t)
 
-		err := api.SendInvitation(roomID, "pl", []string{"jon@doe.com"}, clickmeeting.SetRole(clickmeeting.AsListener))
+		err := api.SendInvitation(context.Background(), roomID, "pl", []string{"jon@doe.com"}, clickmeeting.SetRole(clickmeeting.AsListener))
 		is.NoErr(err)
 	})
 
 	t.Run("ListParticipants", func(t *testing.T) {
 		is := is.New(t)
 
-		people, err := api.GetRegistrations(roomID, "all")
+		people, err := api.GetRegistrations(context.Background(), roomID, "all")
 		is.NoErr(err)
 		is.Equal(len(people), 1)
 		is.Equal(people[0].Email, "jon@doe.com")
 	})
 }
 
+func Test_CreateRoomScheduling(t *testing.T) {
+	var form url.Values
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /conferences.json": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			form = r.PostForm
+			respond(`{"room": {"id": 1}}`)(w, r)
+		},
+		"PUT /conferences/1.json": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			form = r.PostForm
+			respond(`{"conference": {"id": 1}}`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+	newRoom := clickmeeting.NewRoom{Name: "Weekly", RoomType: clickmeeting.Meeting, AccessType: clickmeeting.OpenType}
+
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	startsAt := time.Date(2021, 10, 11, 8, 0, 0, 0, time.UTC)
+
+	t.Run("LocationOfTime", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.CreateRoom(context.Background(), newRoom,
+			clickmeeting.WithStartsAt(startsAt.In(warsaw)),
+			clickmeeting.WithDescription("Weekly sync"),
+			clickmeeting.WithCustomRoomURL("weekly-sync"),
+			clickmeeting.WithRegistrationAndTemplate(2),
+		)
+		is.NoErr(err)
+		is.Equal(form.Get("starts_at"), "2021-10-11 10:00:00")
+		is.Equal(form.Get("timezone"), "Europe/Warsaw")
+		is.Equal(form.Get("description"), "Weekly sync")
+		is.Equal(form.Get("custom_room_url_name"), "weekly-sync")
+		is.Equal(form.Get("registration[template]"), "2")
+	})
+
+	t.Run("FixedZone", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.CreateRoom(context.Background(), newRoom,
+			clickmeeting.WithStartsAt(startsAt.In(time.FixedZone("", 3600))),
+		)
+		is.NoErr(err)
+		is.Equal(form.Get("starts_at"), "2021-10-11 08:00:00")
+		is.Equal(form.Get("timezone"), "UTC")
+	})
+
+	t.Run("ExplicitTimezone", func(t *testing.T) {
+		is := is.New(t)
+
+		withTimezone, err := clickmeeting.TimeZones{"Europe/London", "Europe/Warsaw"}.WithTimezone("Europe/Warsaw")
+		is.NoErr(err)
+		for _, opts := range [][]clickmeeting.CreateRoomOption{
+			{withTimezone, clickmeeting.WithStartsAt(startsAt)},
+			{clickmeeting.WithStartsAt(startsAt), withTimezone},
+		} {
+			_, err := api.CreateRoom(context.Background(), newRoom, opts...)
+			is.NoErr(err)
+			is.Equal(form["starts_at"], []string{"2021-10-11 10:00:00"})
+			is.Equal(form["timezone"], []string{"Europe/Warsaw"})
+		}
+	})
+
+	t.Run("UnknownTimezone", func(t *testing.T) {
+		is := is.New(t)
+
+		form = nil
+		_, err := api.UpdateRoom(context.Background(), 1, clickmeeting.SetStartsAt(startsAt, "Not/AZone"))
+		is.True(err != nil)
+		is.Equal(form, nil) // start time in wrong time zone must not be sent
+
+		_, err = api.CreateRoom(context.Background(), newRoom, clickmeeting.WithStartsAt(startsAt), clickmeeting.WithTimezone("Not/AZone"))
+		is.True(err != nil)
+		is.Equal(form, nil)
+	})
+
+	t.Run("SetStartsAt", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.UpdateRoom(context.Background(), 1, clickmeeting.SetStartsAt(startsAt, "Europe/Warsaw"))
+		is.NoErr(err)
+		is.Equal(form, url.Values{
+			"starts_at": {"2021-10-11 10:00:00"},
+			"timezone":  {"Europe/Warsaw"},
+		})
+	})
+}
+
+func Test_RoomSettingsPatch(t *testing.T) {
+	var (
+		form    url.Values
+		updates int
+	)
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7.json": respond(`{"conference": {"id": 7, "settings": {"show_on_personal_page": true, "thank_you_page_url": "https://example.com"}}}`),
+		"PUT /conferences/7.json": func(w http.ResponseWriter, r *http.Request) {
+			updates++
+			r.ParseForm()
+			form = r.PostForm
+			respond(`{"conference": {"id": 7, "settings": {"show_on_personal_page": true, "recorder_autostart_enabled": true}}}`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("ModifyRoomSettings", func(t *testing.T) {
+		is := is.New(t)
+
+		room, err := api.ModifyRoomSettings(context.Background(), 7, func(s *clickmeeting.RoomSettings) {
+			s.RecorderAutostartEnabled = true
+		})
+		is.NoErr(err)
+		is.True(room.Settings.RecorderAutostartEnabled)
+		is.Equal(updates, 1)
+		is.Equal(form, url.Values{"settings[recorder_autostart_enabled]": {"1"}})
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		is := is.New(t)
+		updates = 0
+
+		room, err := api.ModifyRoomSettings(context.Background(), 7, func(s *clickmeeting.RoomSettings) {
+			s.ShowOnPersonalPage = true
+		})
+		is.NoErr(err)
+		is.Equal(room.ID, 7)
+		is.Equal(updates, 0)
+	})
+
+	t.Run("SetRoomSettingsPatch", func(t *testing.T) {
+		is := is.New(t)
+
+		off, thankYou := false, "https://example.com/thanks"
+		_, err := api.UpdateRoom(context.Background(), 7, clickmeeting.SetRoomSettingsPatch(clickmeeting.RoomSettingsPatch{
+			ShowOnPersonalPage: &off,
+			ThankYouPageUrl:    &thankYou,
+		}))
+		is.NoErr(err)
+		is.Equal(form, url.Values{
+			"settings[show_on_personal_page]": {"0"},
+			"settings[thank_you_page_url]":    {"https://example.com/thanks"},
+		})
+	})
+
+	t.Run("SetRoomSettings", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.UpdateRoom(context.Background(), 7, clickmeeting.SetRoomSettings(clickmeeting.RoomSettings{}))
+		is.NoErr(err)
+		is.Equal(len(form), 9)
+	})
+}
+
+func Test_GetRoom(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7.json": respond(`{"conference": {"id": 7, "name": "Weekly", "name_url": "weekly", "room_url": "https://acme.clickmeeting.com/weekly"}}`),
+		"GET /conferences/active.json": respond(`[
+			{"id": 7, "name": "Weekly", "name_url": "weekly", "room_url": "https://acme.clickmeeting.com/weekly"}
+		]`),
+		"GET /conferences/inactive.json": respond(`[
+			{"id": 8, "name": "Archived", "name_url": "archived", "room_url": "https://acme.clickmeeting.com/archived"}
+		]`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("GetRoom", func(t *testing.T) {
+		is := is.New(t)
+
+		room, err := api.GetRoom(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(room.ID, 7)
+		is.Equal(room.Slug, "weekly")
+	})
+
+	t.Run("FindRoomBySlug", func(t *testing.T) {
+		is := is.New(t)
+
+		room, err := api.FindRoomBySlug(context.Background(), "archived")
+		is.NoErr(err)
+		is.Equal(room.ID, 8)
+
+		room, err = api.FindRoomBySlug(context.Background(), "https://acme.clickmeeting.com/weekly/")
+		is.NoErr(err)
+		is.Equal(room.ID, 7)
+
+		_, err = api.FindRoomBySlug(context.Background(), "missing")
+		is.True(errors.Is(err, clickmeeting.ErrNotFound))
+	})
+
+	t.Run("ParseRoomURL", func(t *testing.T) {
+		is := is.New(t)
+
+		room, err := api.ParseRoomURL(context.Background(), "https://acme.clickmeeting.com/weekly?l=abc")
+		is.NoErr(err)
+		is.Equal(room.ID, 7)
+
+		_, err = api.ParseRoomURL(context.Background(), "https://acme.clickmeeting.com/")
+		is.True(err != nil)
+
+		_, err = api.ParseRoomURL(context.Background(), "https://acme.clickmeeting.com/missing")
+		is.True(errors.Is(err, clickmeeting.ErrNotFound))
+	})
+}
+
+func Test_Sessions(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7/sessions.json": respond(`[
+			{"id": 1, "total_visitors": 3, "max_visitors": 2, "start_date": "2021-10-11T10:00:00+00:00", "end_date": "2021-10-11T11:00:00+00:00"},
+			{"id": 2, "total_visitors": 0, "max_visitors": 0, "start_date": "2021-10-12T10:00:00+00:00", "end_date": "2021-10-12T10:05:00+00:00"}
+		]`),
+		"GET /conferences/7/sessions/1.json": respond(`{
+			"max_visitors": 2,
+			"total_visitors": 3,
+			"start_date": "2021-10-11T10:00:00+00:00",
+			"end_date": "2021-10-11T11:00:00+00:00",
+			"attendees": [
+				{"id": 11, "email": "jon@doe.com", "login": "Jon", "start_date": "2021-10-11T10:01:00+00:00", "end_date": "2021-10-11T10:59:00+00:00"}
+			],
+			"pdf": {
+				"en": {"generate_pdf_url": "https://example.com/en.pdf", "progress": 100}
+			}
+		}`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("GetSessions", func(t *testing.T) {
+		is := is.New(t)
+
+		sessions, err := api.GetSessions(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(len(sessions), 2)
+		is.Equal(sessions[0].ID, 1)
+		is.Equal(sessions[0].TotalVisitors, 3)
+		is.Equal(sessions[1].EndDate, time.Date(2021, 10, 12, 10, 5, 0, 0, time.UTC).In(sessions[1].EndDate.Location()))
+	})
+
+	t.Run("GetSession", func(t *testing.T) {
+		is := is.New(t)
+
+		session, err := api.GetSession(context.Background(), 7, 1)
+		is.NoErr(err)
+		is.Equal(session.MaxVisitors, 2)
+		is.Equal(len(session.Attendees), 1)
+		is.Equal(session.Attendees[0].Email, "jon@doe.com")
+		is.Equal(session.Attendees[0].Login, "Jon")
+		is.Equal(session.PDF["en"].Progress, 100)
+		is.Equal(session.PDF["en"].URL, "https://example.com/en.pdf")
+	})
+
+	t.Run("GetSessionNotFound", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.GetSession(context.Background(), 7, 2)
+		var apiErr clickmeeting.APIError
+		is.True(errors.As(err, &apiErr))
+		is.Equal(apiErr.Code, http.StatusNotFound)
+	})
+}
+
+func Test_PDFReport(t *testing.T) {
+	var polls int
+	srv := newTestServer(t, nil)
+	routes := map[string]http.HandlerFunc{
+		"GET /conferences/7/sessions/1/generate-pdf/en.json": respond(`{"status": "PROCESSING", "progress": 0, "url": ""}`),
+		"GET /conferences/7/sessions/1.json": func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			progress := 50
+			if polls >= 3 {
+				progress = 100
+			}
+			respond(fmt.Sprintf(`{"pdf": {"en": {"generate_pdf_url": "%s/reports/1.pdf", "progress": %d}}}`, srv.URL, progress))(w, r)
+		},
+		"GET /conferences/7/sessions/2.json": respond(`{"pdf": {"en": {"generate_pdf_url": "", "progress": 50}}}`),
+		"GET /reports/1.pdf": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "%PDF-1")
+		},
+	}
+	srv.Config.Handler = authorized(routes)
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("GeneratePDFReport", func(t *testing.T) {
+		is := is.New(t)
+
+		pdf, err := api.GeneratePDFReport(context.Background(), 7, 1, "en")
+		is.NoErr(err)
+		is.Equal(pdf.Progress, 0)
+	})
+
+	t.Run("WaitForPDFReport", func(t *testing.T) {
+		is := is.New(t)
+
+		var buf bytes.Buffer
+		_, err := api.WaitForPDFReport(context.Background(), 7, 1, "en", time.Millisecond, &buf)
+		is.NoErr(err)
+		is.Equal(polls, 3)
+		is.Equal(buf.String(), "%PDF-1")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		is := is.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := api.WaitForPDFReport(ctx, 7, 2, "en", time.Millisecond, io.Discard)
+		is.True(errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func Test_AccessTokens(t *testing.T) {
+	var requested []int
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /conferences/7/tokens.json": func(w http.ResponseWriter, r *http.Request) {
+			n, _ := strconv.Atoi(r.FormValue("how_many"))
+			requested = append(requested, n)
+
+			tokens := make([]string, 0, n)
+			for i := 0; i < n; i++ {
+				tokens = append(tokens, fmt.Sprintf(`{"token": "T%d", "sent_to_email": null, "first_use_date": null}`, i))
+			}
+			respond(`{"access_tokens": [`+strings.Join(tokens, ",")+`]}`)(w, r)
+		},
+		"GET /conferences/7/tokens.json": respond(`{"access_tokens": [
+			{"token": "SS6RG6", "sent_to_email": null, "first_use_date": null},
+			{"token": "XJ2PLQ", "sent_to_email": "jon@doe.com", "first_use_date": "2021-10-11T10:01:00+00:00"}
+		]}`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("GenerateAccessTokens", func(t *testing.T) {
+		is := is.New(t)
+
+		tokens, err := api.GenerateAccessTokens(context.Background(), 7, 2500)
+		is.NoErr(err)
+		is.Equal(len(tokens), 2500)
+		is.Equal(requested, []int{1000, 1000, 500})
+		is.Equal(tokens[0].FirstUseData, nil)
+	})
+
+	t.Run("GenerateAccessTokensInvalid", func(t *testing.T) {
+		is := is.New(t)
+		requested = nil
+
+		tokens, err := api.GenerateAccessTokens(context.Background(), 7, 0)
+		is.NoErr(err)
+		is.Equal(len(tokens), 0)
+
+		_, err = api.GenerateAccessTokens(context.Background(), 7, -1)
+		is.True(err != nil)
+		is.Equal(len(requested), 0)
+	})
+
+	t.Run("GetAccessTokens", func(t *testing.T) {
+		is := is.New(t)
+
+		tokens, err := api.GetAccessTokens(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(len(tokens), 2)
+		is.Equal(tokens[0].SentToEmail, "")
+		is.Equal(tokens[1].SentToEmail, "jon@doe.com")
+		is.True(tokens[1].FirstUseData != nil)
+		is.True(tokens[1].FirstUseData.Equal(time.Date(2021, 10, 11, 10, 1, 0, 0, time.UTC)))
+	})
+}
+
+func Test_AutoLogin(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /conferences/7/room/autologin_hash.json": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("email") != "jon@doe.com" || r.FormValue("nickname") != "Jon" ||
+				r.FormValue("role") != "host" || r.FormValue("token") != "SS6RG6" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"code": 422, "name": "Unprocessable Entity", "errors": []}`)
+				return
+			}
+			respond(`{"autologin_hash": "abc123"}`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	is := is.New(t)
+
+	hash, err := api.AutoLoginHash(context.Background(), 7,
+		clickmeeting.WithLoginEmail("jon@doe.com"),
+		clickmeeting.WithLoginNickname("Jon"),
+		clickmeeting.WithLoginRole(clickmeeting.LoginAsHost),
+		clickmeeting.WithLoginToken("SS6RG6"),
+	)
+	is.NoErr(err)
+	is.Equal(hash, "abc123")
+
+	link, err := clickmeeting.AutoLoginURL(clickmeeting.Room{RoomURL: "https://myaccount.clickmeeting.com/my-room"}, hash)
+	is.NoErr(err)
+	is.Equal(link, "https://myaccount.clickmeeting.com/my-room?l=abc123")
+}
+
+func Test_SessionParticipants(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7/sessions/1/attendees.json": respond(`[
+			{"id": 11, "nickname": "Jon", "email": "jon@doe.com", "role": "presenter", "device": "desktop",
+			 "start_date": "2021-10-11T10:01:00+00:00", "end_date": "2021-10-11T10:59:00+00:00"},
+			{"id": 12, "nickname": "Jane", "email": "jane@doe.com", "role": "listener", "device": "mobile",
+			 "start_date": "2021-10-11T10:05:00+00:00", "end_date": "2021-10-11T10:30:00+00:00"},
+			{"id": 13, "nickname": "Host", "email": "host@doe.com", "role": "host", "device": "desktop",
+			 "start_date": "2021-10-11T10:00:00+00:00", "end_date": "2021-10-11T11:00:00+00:00"}
+		]`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	is := is.New(t)
+
+	participants, err := api.GetParticipants(context.Background(), 7, 1)
+	is.NoErr(err)
+	is.Equal(len(participants), 3)
+	is.Equal(participants[0].Role, clickmeeting.PresenterRole)
+	is.Equal(participants[2].Role, clickmeeting.HostRole)
+	is.Equal(participants[1].Device, "mobile")
+	is.Equal(participants[1].LeftAt.Sub(participants[1].JoinedAt), 25*time.Minute)
+}
+
+func Test_ClientOptions(t *testing.T) {
+	is := is.New(t)
+
+	var userAgent string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/active.json": func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.UserAgent()
+			respond(`[{"id": 1}]`)(w, r)
+		},
+	})
+
+	var roundTrips int
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL+"/"),
+		clickmeeting.WithUserAgent("my-service/1.0"),
+		clickmeeting.WithRoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			roundTrips++
+			return http.DefaultTransport.RoundTrip(r)
+		})),
+		clickmeeting.WithTimeout(time.Second),
+	)
+
+	rooms, err := api.ListRooms(context.Background(), clickmeeting.ActiveRoom)
+	is.NoErr(err)
+	is.Equal(len(rooms), 1)
+	is.Equal(userAgent, "my-service/1.0")
+	is.Equal(roundTrips, 1)
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_Recordings(t *testing.T) {
+	var deleted []string
+	deleteHandler := func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.URL.Path)
+		respond(`{"result": "OK"}`)(w, r)
+	}
+
+	var storageKey string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		storageKey = r.Header.Get("X-Api-Key")
+		fmt.Fprint(w, "recording-bytes")
+	}))
+	t.Cleanup(storage.Close)
+
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7/recordings.json": respond(`[
+			{"id": 3, "name": "Weekly", "recording_file_size": 15, "recording_duration": 3600,
+			 "recording_url": "` + storage.URL + `/rec.mp4", "recorder_started": "2021-10-11T10:00:00+00:00"}
+		]`),
+		"DELETE /conferences/7/recordings/3.json": deleteHandler,
+		"DELETE /conferences/7/recordings.json":   deleteHandler,
+		"GET /file-library/5/download": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, storage.URL+"/file.pdf", http.StatusFound)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	var recordings []clickmeeting.Recording
+	t.Run("ListRecordings", func(t *testing.T) {
+		is := is.New(t)
+
+		var err error
+		recordings, err = api.ListRecordings(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(len(recordings), 1)
+		is.Equal(recordings[0].ID, 3)
+		is.Equal(recordings[0].Size, int64(15))
+		is.Equal(recordings[0].Duration, time.Hour)
+		is.True(recordings[0].CreatedAt.Equal(time.Date(2021, 10, 11, 10, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("DownloadRecording", func(t *testing.T) {
+		is := is.New(t)
+
+		var buf bytes.Buffer
+		n, err := api.DownloadRecording(context.Background(), recordings[0], &buf)
+		is.NoErr(err)
+		is.Equal(n, int64(15))
+		is.Equal(buf.String(), "recording-bytes")
+		is.Equal(storageKey, "") // api key must not leak to storage
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		is := is.New(t)
+
+		storageKey = "unset"
+		var buf bytes.Buffer
+		_, err := api.DownloadFile(context.Background(), 5, &buf)
+		is.NoErr(err)
+		is.Equal(buf.String(), "recording-bytes")
+		is.Equal(storageKey, "") // api key must not leak to storage through redirect
+	})
+
+	t.Run("DeleteRecording", func(t *testing.T) {
+		is := is.New(t)
+
+		is.NoErr(api.DeleteRecording(context.Background(), 7, 3))
+		is.NoErr(api.DeleteAllRecordings(context.Background(), 7))
+		is.Equal(deleted, []string{"/conferences/7/recordings/3.json", "/conferences/7/recordings.json"})
+	})
+}
+
+func Test_Files(t *testing.T) {
+	var uploaded string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /file-library/conferences/7.json": func(w http.ResponseWriter, r *http.Request) {
+			f, header, err := r.FormFile("uploaded")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code": 400, "name": "Bad Request", "errors": []}`)
+				return
+			}
+			defer f.Close()
+			d, _ := io.ReadAll(f)
+			uploaded = header.Filename + ":" + string(d)
+			respond(`{"id": 5, "name": "slides.pdf", "type": "pdf", "size": 6, "status": "converting"}`)(w, r)
+		},
+		"GET /file-library.json":               respond(`[{"id": 5, "name": "slides.pdf"}, {"id": 6, "name": "intro.pptx"}]`),
+		"GET /file-library/conferences/7.json": respond(`[{"id": 5, "name": "slides.pdf", "document_pages": 12}]`),
+		"GET /file-library/5/download": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "%PDF-1")
+		},
+		"DELETE /file-library/5.json": respond(`{"result": "OK"}`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("UploadFile", func(t *testing.T) {
+		is := is.New(t)
+
+		var progress int64
+		file, err := api.UploadFile(context.Background(), 7, "slides.pdf", strings.NewReader("%PDF-1"), func(n int64) {
+			progress = n
+		})
+		is.NoErr(err)
+		is.Equal(file.ID, 5)
+		is.Equal(file.Status, "converting")
+		is.Equal(uploaded, "slides.pdf:%PDF-1")
+		is.Equal(progress, int64(6))
+	})
+
+	t.Run("ListFiles", func(t *testing.T) {
+		is := is.New(t)
+
+		files, err := api.ListFiles(context.Background())
+		is.NoErr(err)
+		is.Equal(len(files), 2)
+
+		files, err = api.ListRoomFiles(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(len(files), 1)
+		is.Equal(files[0].Pages, 12)
+	})
+
+	t.Run("DownloadFile", func(t *testing.T) {
+		is := is.New(t)
+
+		var buf bytes.Buffer
+		_, err := api.DownloadFile(context.Background(), 5, &buf)
+		is.NoErr(err)
+		is.Equal(buf.String(), "%PDF-1")
+	})
+
+	t.Run("DeleteFile", func(t *testing.T) {
+		is := is.New(t)
+
+		is.NoErr(api.DeleteFile(context.Background(), 5))
+		is.True(errors.Is(api.DeleteFile(context.Background(), 6), clickmeeting.ErrNotFound))
+	})
+}
+
+func Test_SlowTransfer(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /file-library/5/download": func(w http.ResponseWriter, r *http.Request) {
+			for i := 0; i < 4; i++ {
+				fmt.Fprint(w, "part")
+				w.(http.Flusher).Flush()
+				time.Sleep(30 * time.Millisecond)
+			}
+		},
+		"GET /file-library.json": func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+			respond(`[]`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL),
+		clickmeeting.WithTimeout(50*time.Millisecond),
+		clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{}),
+	)
+
+	t.Run("Download", func(t *testing.T) {
+		is := is.New(t)
+
+		var buf bytes.Buffer
+		_, err := api.DownloadFile(context.Background(), 5, &buf)
+		is.NoErr(err) // download is not cut off by the timeout
+		is.Equal(buf.String(), "partpartpartpart")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.ListFiles(context.Background())
+		is.True(err != nil) // other requests are still limited
+	})
+}
+
+func Test_ReferenceData(t *testing.T) {
+	var form url.Values
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /time_zone_list.json":    respond(`["Europe/Warsaw", "Europe/London", "America/New_York"]`),
+		"GET /time_zone_list/pl.json": respond(`["Europe/Warsaw"]`),
+		"GET /phone_gateways.json":    respond(`[{"id": 1, "country": "PL", "city": "Warsaw", "number": "+48 22 000 00 00"}]`),
+		"GET /conferences/skins.json": respond(`[{"id": 1, "name": "Default"}, {"id": 4, "name": "Dark", "preview": "https://example.com/dark.png"}]`),
+		"POST /conferences.json": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			form = r.PostForm
+			respond(`{"room": {"id": 1}}`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	is := is.New(t)
+
+	zones, err := api.ListTimeZones(ctx, "")
+	is.NoErr(err)
+	is.Equal(len(zones), 3)
+
+	zones, err = api.ListTimeZones(ctx, "PL")
+	is.NoErr(err)
+	tz, err := zones.Find("Europe/Warsaw")
+	is.NoErr(err)
+	_, err = zones.Find("Europe/London")
+	is.True(errors.Is(err, clickmeeting.ErrNotFound))
+
+	gateways, err := api.ListPhoneGateways(ctx)
+	is.NoErr(err)
+	is.Equal(gateways[0].Number, "+48 22 000 00 00")
+
+	skins, err := api.ListSkins(ctx)
+	is.NoErr(err)
+	skin, err := skins.Find("Dark")
+	is.NoErr(err)
+	is.Equal(skin.PreviewURL, "https://example.com/dark.png")
+
+	withTimezone, err := zones.WithTimezone(string(tz))
+	is.NoErr(err)
+	withSkin, err := skins.WithSkin(skin.Name)
+	is.NoErr(err)
+	_, err = api.CreateRoom(ctx, clickmeeting.NewRoom{Name: "Testing"}, withTimezone, withSkin)
+	is.NoErr(err)
+	is.Equal(form.Get("timezone"), "Europe/Warsaw")
+	is.Equal(form.Get("skin_id"), "4")
+
+	_, err = zones.SetTimezone("Europe/London")
+	is.True(errors.Is(err, clickmeeting.ErrNotFound))
+	_, err = skins.SetSkin("Light")
+	is.True(errors.Is(err, clickmeeting.ErrNotFound))
+}
+
+func Test_Contacts(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		added    []string
+		inFlight int
+		maxLoad  int
+	)
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /contacts.json": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxLoad {
+				maxLoad = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			if !strings.Contains(r.FormValue("email"), "@") {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(w, `{"code": 422, "name": "Unprocessable Entity", "errors": [{"name": "email", "message": "Invalid email"}]}`)
+				return
+			}
+			mu.Lock()
+			added = append(added, r.FormValue("firstname")+" "+r.FormValue("lastname"))
+			mu.Unlock()
+			respond(`{"result": "OK"}`)(w, r)
+		},
+		"GET /contacts.json": respond(`[{"email": "jon@doe.com", "firstname": "Jon", "lastname": "Doe", "phone": "123", "company": "ACME"}]`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("AddContact", func(t *testing.T) {
+		is := is.New(t)
+
+		err := api.AddContact(context.Background(), clickmeeting.Contact{Email: "jon@doe.com", FirstName: "Jon", LastName: "Doe"})
+		is.NoErr(err)
+		is.Equal(added, []string{"Jon Doe"})
+	})
+
+	t.Run("ListContacts", func(t *testing.T) {
+		is := is.New(t)
+
+		contacts, err := api.ListContacts(context.Background())
+		is.NoErr(err)
+		is.Equal(contacts, []clickmeeting.Contact{{Email: "jon@doe.com", FirstName: "Jon", LastName: "Doe", Phone: "123", Company: "ACME"}})
+	})
+
+	t.Run("ImportContacts", func(t *testing.T) {
+		is := is.New(t)
+
+		contacts := make([]clickmeeting.Contact, 10)
+		for i := range contacts {
+			contacts[i] = clickmeeting.Contact{Email: fmt.Sprintf("user%d@doe.com", i), FirstName: "User", LastName: strconv.Itoa(i)}
+		}
+		contacts[3].Email = "invalid"
+
+		results := api.ImportContacts(context.Background(), contacts, 3)
+		is.Equal(len(results), 10)
+		for i, res := range results {
+			is.Equal(res.Contact, contacts[i])
+			if i == 3 {
+				is.True(errors.Is(res.Err, clickmeeting.ErrValidation))
+				continue
+			}
+			is.NoErr(res.Err)
+		}
+		is.True(maxLoad <= 3)
+	})
+}
+
+func Test_UploadFileCanceled(t *testing.T) {
+	is := is.New(t)
+
+	srv := newTestServer(t, nil)
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL),
+		clickmeeting.WithRateLimit(1, 1),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		_, err := api.UploadFile(ctx, 7, "slides.pdf", strings.NewReader("%PDF-1"), nil)
+		is.True(errors.Is(err, context.Canceled))
+	}
+
+	// Upload writers exit asynchronously once the pipe is closed.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	is.True(runtime.NumGoroutine() <= before) // upload writer goroutines leaked
+}
+
+func Test_Context(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/active.json": func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("Deadline", func(t *testing.T) {
+		is := is.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := api.ListRooms(ctx, clickmeeting.ActiveRoom)
+		is.True(errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		is := is.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := api.ListRooms(ctx, clickmeeting.ActiveRoom)
+		is.True(errors.Is(err, context.Canceled))
+	})
+}
+
+// newTestServer starts a stand-in ClickMeeting API with handlers keyed by "METHOD /path".
+// Unknown routes answer with a 404 APIError.
+func newTestServer(t *testing.T, routes map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(authorized(routes))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// authorized returns handler routing requests with valid api key.
+func authorized(routes map[string]http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code": 401, "name": "Unauthorized", "errors": []}`)
+			return
+		}
+		handler, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code": 404, "name": "Not Found", "errors": []}`)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// respond returns a handler writing body as a JSON response.
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}
+}
+
 func pp(d interface{}) {
 	r, _ := json.MarshalIndent(d, "", "\t")
 	fmt.Println(string(r))
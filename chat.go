@@ -0,0 +1,91 @@
+package clickmeeting
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ParseChatArchive parses zip archive returned by the chats endpoint. Every file in the archive
+// holds one message per line:
+//
+//	2021-10-11 10:01:00 Jon Doe: Hello everyone
+//	2021-10-11 10:02:00 Jon Doe -> Jane Doe: Private message
+//
+// Lines not starting with a timestamp continue the previous message. Messages are sorted by time.
+// Timestamps are wall-clock times of the room, they are interpreted in loc, or in UTC when loc is nil.
+func ParseChatArchive(r io.ReaderAt, size int64, loc *time.Location) ([]ChatMessage, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chat archive: %w", err)
+	}
+
+	var messages []ChatMessage
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		msgs, err := parseChat(rc, loc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		messages = append(messages, msgs...)
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Time.Before(messages[j].Time)
+	})
+	return messages, nil
+}
+
+const chatTimeLayout = "2006-01-02 15:04:05"
+
+// maxChatLine limits length of a single line of a chat file.
+const maxChatLine = 4 << 20
+
+var chatLine = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) (.+?)(?: -> (.+?))?: (.*)$`)
+
+func parseChat(r io.Reader, loc *time.Location) ([]ChatMessage, error) {
+	var messages []ChatMessage
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxChatLine)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		m := chatLine.FindStringSubmatch(line)
+		if m == nil {
+			if len(messages) > 0 {
+				last := &messages[len(messages)-1]
+				last.Text += "\n" + line
+			}
+			continue
+		}
+
+		t, err := time.ParseInLocation(chatTimeLayout, m[1], loc)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, ChatMessage{
+			Time:      t,
+			Author:    m[2],
+			Recipient: m[3],
+			Private:   m[3] != "",
+			Text:      m[4],
+		})
+	}
+	return messages, sc.Err()
+}
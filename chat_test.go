@@ -0,0 +1,71 @@
+package clickmeeting_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_Chats(t *testing.T) {
+	long := strings.Repeat("a", 100<<10)
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	f, _ := zw.Create("chat_1.txt")
+	f.Write([]byte("" +
+		"2021-10-11 10:02:00 Jon Doe -> Jane Doe: see you after\r\n" +
+		"2021-10-11 10:01:00 Jon Doe: Hello everyone\r\n" +
+		"and welcome\r\n" +
+		"2021-10-11 10:03:00 Jane Doe: Time: 10:03\r\n"))
+	f, _ = zw.Create("chat_2.txt")
+	f.Write([]byte("2021-10-11 10:04:00 Jane Doe: " + long + "\n"))
+	zw.Close()
+
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /chats.json": respond(`[{"id": 1, "name": "Weekly", "date": "2021-10-11", "time": "10:00", "download_link": "https://example.com/chats/1"}]`),
+		"GET /chats/1": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/zip")
+			w.Write(archive.Bytes())
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	t.Run("ListChats", func(t *testing.T) {
+		is := is.New(t)
+
+		chats, err := api.ListChats(context.Background())
+		is.NoErr(err)
+		is.Equal(len(chats), 1)
+		is.Equal(chats[0].SessionID, 1)
+	})
+
+	t.Run("GetChat", func(t *testing.T) {
+		is := is.New(t)
+
+		messages, err := api.GetChat(context.Background(), 1, nil)
+		is.NoErr(err)
+		is.Equal(messages, []clickmeeting.ChatMessage{
+			{Time: time.Date(2021, 10, 11, 10, 1, 0, 0, time.UTC), Author: "Jon Doe", Text: "Hello everyone\nand welcome"},
+			{Time: time.Date(2021, 10, 11, 10, 2, 0, 0, time.UTC), Author: "Jon Doe", Text: "see you after", Private: true, Recipient: "Jane Doe"},
+			{Time: time.Date(2021, 10, 11, 10, 3, 0, 0, time.UTC), Author: "Jane Doe", Text: "Time: 10:03"},
+			{Time: time.Date(2021, 10, 11, 10, 4, 0, 0, time.UTC), Author: "Jane Doe", Text: long},
+		})
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		is := is.New(t)
+
+		warsaw, err := time.LoadLocation("Europe/Warsaw")
+		is.NoErr(err)
+		messages, err := api.GetChat(context.Background(), 1, warsaw)
+		is.NoErr(err)
+		is.True(messages[0].Time.Equal(time.Date(2021, 10, 11, 8, 1, 0, 0, time.UTC)))
+		is.Equal(messages[0].Time.Location(), warsaw)
+	})
+}
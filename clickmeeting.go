@@ -1,22 +1,60 @@
 package clickmeeting
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 type Client interface {
-	ListRooms(status RoomStatus) ([]Room, error)
-	CreateRoom(room NewRoom, opts ...CreateRoomOption) (Room, error)
-	UpdateRoom(roomID int, opts ...UpdateRoomOption) (Room, error)
-	DeleteRoom(roomID int) error
+	Ping(ctx context.Context) error
+
+	ListRooms(ctx context.Context, status RoomStatus) ([]Room, error)
+	GetRoom(ctx context.Context, roomID int) (Room, error)
+	FindRoomBySlug(ctx context.Context, slug string) (Room, error)
+	ParseRoomURL(ctx context.Context, roomURL string) (Room, error)
+	CreateRoom(ctx context.Context, room NewRoom, opts ...CreateRoomOption) (Room, error)
+	UpdateRoom(ctx context.Context, roomID int, opts ...UpdateRoomOption) (Room, error)
+	ModifyRoomSettings(ctx context.Context, roomID int, modify func(s *RoomSettings)) (Room, error)
+	Reconcile(ctx context.Context, roomID int, desired DesiredRoom) (RoomDiff, error)
+	PlanReconcile(ctx context.Context, roomID int, desired DesiredRoom) (RoomDiff, error)
+	DeleteRoom(ctx context.Context, roomID int) error
+
+	GetSessions(ctx context.Context, roomID int) ([]SessionSummary, error)
+	GetSession(ctx context.Context, roomID int, sessionID int) (Session, error)
+	GeneratePDFReport(ctx context.Context, roomID int, sessionID int, lang string) (PDFSummary, error)
+	WaitForPDFReport(ctx context.Context, roomID int, sessionID int, lang string, interval time.Duration, w io.Writer) (int64, error)
+
+	GenerateAccessTokens(ctx context.Context, roomID int, howMany int) ([]AccessToken, error)
+	GetAccessTokens(ctx context.Context, roomID int) ([]AccessToken, error)
+
+	AutoLoginHash(ctx context.Context, roomID int, opts ...AutoLoginOption) (string, error)
+
+	SendInvitation(ctx context.Context, roomID int, language string, attendees []string, opts ...SendInvitationOption) error
+
+	GetRegistrations(ctx context.Context, roomID int, status string) ([]Participant, error)
+	RegisterParticipant(ctx context.Context, roomID int, participant NewParticipant, opts ...RegisterParticipantOption) (string, error)
+	GetParticipants(ctx context.Context, roomID int, sessionID int) ([]SessionParticipant, error)
 
-	GetSessions(roomID int) ([]SessionSummary, error)
-	GetSession(roomID int, sessionID int) (Session, error)
+	ListRecordings(ctx context.Context, roomID int) ([]Recording, error)
+	DownloadRecording(ctx context.Context, recording Recording, w io.Writer) (int64, error)
+	DeleteRecording(ctx context.Context, roomID int, recordingID int) error
+	DeleteAllRecordings(ctx context.Context, roomID int) error
 
-	GenerateAccessTokens(roomID int, howMany int) ([]AccessToken, error)
-	GetAccessTokens(roomID int) ([]AccessToken, error)
+	UploadFile(ctx context.Context, roomID int, filename string, r io.Reader, progress ProgressFunc) (File, error)
+	ListFiles(ctx context.Context) ([]File, error)
+	ListRoomFiles(ctx context.Context, roomID int) ([]File, error)
+	DownloadFile(ctx context.Context, fileID int, w io.Writer) (int64, error)
+	DeleteFile(ctx context.Context, fileID int) error
 
-	AutoLoginHash(roomID int) (string, error)
+	ListChats(ctx context.Context) ([]Chat, error)
+	GetChat(ctx context.Context, sessionID int, loc *time.Location) ([]ChatMessage, error)
 
-	SendInvitation(roomID int, language string, attendees []string, opts ...SendInvitationOption) error
+	ListTimeZones(ctx context.Context, country string) (TimeZones, error)
+	ListPhoneGateways(ctx context.Context) ([]PhoneGateway, error)
+	ListSkins(ctx context.Context) (Skins, error)
 
-	GetRegistrations(roomID int, status string) ([]Participant, error)
-	RegisterParticipant(roomID int, participant NewParticipant, opts ...RegisterParticipantOption) (string, error)
-	GetParticipants(roomID int, sessionID int) ([]Participant, error)
+	AddContact(ctx context.Context, contact Contact) error
+	ListContacts(ctx context.Context) ([]Contact, error)
+	ImportContacts(ctx context.Context, contacts []Contact, concurrency int) []ContactResult
 }
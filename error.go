@@ -1,29 +1,160 @@
 package clickmeeting
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"strings"
 )
 
+// Errors matched by APIError based on its Code, e.g. errors.Is(err, ErrNotFound).
+var (
+	ErrValidation   = errors.New("clickmeeting: validation failed")
+	ErrUnauthorized = errors.New("clickmeeting: unauthorized")
+	ErrForbidden    = errors.New("clickmeeting: forbidden")
+	ErrNotFound     = errors.New("clickmeeting: not found")
+	ErrRateLimited  = errors.New("clickmeeting: rate limited")
+	ErrServer       = errors.New("clickmeeting: server error")
+)
+
+// Errors returned by Ping.
+var (
+	ErrUnreachable   = errors.New("clickmeeting: api unreachable")
+	ErrInvalidAPIKey = errors.New("clickmeeting: invalid api key")
+	//ErrWrongRegion means the api key belongs to an account in a different region than the API.
+	ErrWrongRegion = errors.New("clickmeeting: api key from a different region")
+)
+
 type APIError struct {
-	Code   int    `json:"code"`
-	Name   string `json:"name"`
-	Errors []struct {
-		Name    interface{} `json:"name"`
-		Message string      `json:"message"`
-	} `json:"errors"`
+	Code   int          `json:"code"`
+	Name   string       `json:"name"`
+	Errors []FieldError `json:"errors"`
+}
+
+// Field returns error reported for given form key, e.g. "settings[thank_you_page_url]".
+func (e APIError) Field(key string) (FieldError, bool) {
+	for _, fe := range e.Errors {
+		if fe.Field == key {
+			return fe, true
+		}
+	}
+	return FieldError{}, false
 }
 
 func (e APIError) Error() string {
 	errors := make([]string, 0, len(e.Errors))
 	for _, err := range e.Errors {
-		errors = append(errors, err.Message)
+		errors = append(errors, err.Error())
 	}
 
 	return fmt.Sprintf("%d-%s: %s", e.Code, e.Name, strings.Join(errors, ","))
 }
 
+// Is reports whether e matches one of the sentinel errors,
+// or an APIError with the same Code. APIError with zero Code matches any APIError.
 func (e APIError) Is(target error) bool {
-	_, ok := target.(APIError)
-	return ok
+	if t, ok := target.(APIError); ok {
+		return t.Code == 0 || t.Code == e.Code
+	}
+	return codeIs(e.Code, target)
+}
+
+func codeIs(code int, target error) bool {
+	switch target {
+	case ErrValidation:
+		return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrServer:
+		return code >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// maxErrorBody limits how much of an error response is read, and maxErrorSnippet how much of it is kept.
+const (
+	maxErrorBody    = 64 << 10
+	maxErrorSnippet = 1 << 10
+)
+
+// ResponseError is returned when the API responds with an error that is not a valid APIError,
+// e.g. an HTML page served by a gateway or an empty body.
+type ResponseError struct {
+	StatusCode int
+	Header     http.Header
+	//Body holds the beginning of the response body.
+	Body []byte
+	//Err is the reason body could not be decoded as APIError.
+	Err error
+}
+
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("unexpected response %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if body := strings.TrimSpace(string(e.Body)); body != "" {
+		msg += ": " + body
+	}
+	return msg
+}
+
+// Is reports whether e matches one of the sentinel errors based on its StatusCode.
+func (e *ResponseError) Is(target error) bool {
+	return codeIs(e.StatusCode, target)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError reads error response into APIError, falling back to ResponseError.
+func decodeError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err == nil {
+		var apiErr APIError
+		err = json.Unmarshal(body, &apiErr)
+		if err == nil && apiErr.Code == 0 && apiErr.Name == "" {
+			err = errors.New("response is not an api error")
+		}
+		if err == nil {
+			if apiErr.Code == 0 {
+				apiErr.Code = resp.StatusCode
+			}
+			return apiErr
+		}
+	}
+
+	if len(body) > maxErrorSnippet {
+		body = body[:maxErrorSnippet]
+	}
+	return &ResponseError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Err:        err,
+	}
+}
+
+// IsRetryable reports whether request that failed with err might succeed when sent again.
+// Like the client's own retries, it covers rate limiting, server errors and any failure
+// to send the request, such as refused or reset connection.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) {
+		return true
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
 }
@@ -0,0 +1,169 @@
+package clickmeeting_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_ErrorSentinels(t *testing.T) {
+	is := is.New(t)
+
+	tests := []struct {
+		code      int
+		sentinel  error
+		retryable bool
+	}{
+		{http.StatusBadRequest, clickmeeting.ErrValidation, false},
+		{http.StatusUnprocessableEntity, clickmeeting.ErrValidation, false},
+		{http.StatusUnauthorized, clickmeeting.ErrUnauthorized, false},
+		{http.StatusForbidden, clickmeeting.ErrForbidden, false},
+		{http.StatusNotFound, clickmeeting.ErrNotFound, false},
+		{http.StatusTooManyRequests, clickmeeting.ErrRateLimited, true},
+		{http.StatusInternalServerError, clickmeeting.ErrServer, true},
+		{http.StatusBadGateway, clickmeeting.ErrServer, true},
+	}
+	sentinels := []error{
+		clickmeeting.ErrValidation,
+		clickmeeting.ErrUnauthorized,
+		clickmeeting.ErrForbidden,
+		clickmeeting.ErrNotFound,
+		clickmeeting.ErrRateLimited,
+		clickmeeting.ErrServer,
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", clickmeeting.APIError{Code: tt.code})
+		for _, sentinel := range sentinels {
+			is.Equal(errors.Is(err, sentinel), sentinel == tt.sentinel) // sentinel match for code
+		}
+		is.Equal(clickmeeting.IsRetryable(err), tt.retryable)
+		is.True(errors.Is(err, clickmeeting.APIError{}))
+		is.True(errors.Is(err, clickmeeting.APIError{Code: tt.code}))
+		is.True(!errors.Is(err, clickmeeting.APIError{Code: 1}))
+	}
+
+	is.True(!clickmeeting.IsRetryable(nil))
+	is.True(!clickmeeting.IsRetryable(context.DeadlineExceeded))
+}
+
+func Test_IsRetryableTransportError(t *testing.T) {
+	is := is.New(t)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(closed.URL), clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{MaxAttempts: 1}))
+	_, err := api.GetSessions(context.Background(), 1)
+	is.True(err != nil)
+	is.True(clickmeeting.IsRetryable(err)) // connection refused is retried by the client as well
+}
+
+func Test_ErrorFromServer(t *testing.T) {
+	is := is.New(t)
+
+	srv := newTestServer(t, map[string]http.HandlerFunc{})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	_, err := api.GetSession(context.Background(), 7, 1)
+	is.True(errors.Is(err, clickmeeting.ErrNotFound))
+
+	api = clickmeeting.NewAPIWithOptions("wrong", clickmeeting.WithBaseURL(srv.URL))
+	_, err = api.GetSession(context.Background(), 7, 1)
+	is.True(errors.Is(err, clickmeeting.ErrUnauthorized))
+}
+
+func Test_ResponseError(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/1/sessions.json": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html><body>Bad Gateway</body></html>"+strings.Repeat(" ", 4096))
+		},
+		"GET /conferences/2/sessions.json": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+		"GET /conferences/3/sessions.json": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "no such room"}`)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL), clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{}))
+
+	t.Run("HTML", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.GetSessions(context.Background(), 1)
+		var respErr *clickmeeting.ResponseError
+		is.True(errors.As(err, &respErr))
+		is.Equal(respErr.StatusCode, http.StatusBadGateway)
+		is.Equal(respErr.Header.Get("Content-Type"), "text/html")
+		is.Equal(len(respErr.Body), 1024)
+		is.True(strings.HasPrefix(err.Error(), "unexpected response 502 Bad Gateway: <html>"))
+		is.True(errors.Is(err, clickmeeting.ErrServer))
+		is.True(clickmeeting.IsRetryable(err))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.GetSessions(context.Background(), 2)
+		var respErr *clickmeeting.ResponseError
+		is.True(errors.As(err, &respErr))
+		is.Equal(len(respErr.Body), 0)
+		is.Equal(err.Error(), "unexpected response 503 Service Unavailable")
+		is.True(errors.Is(err, clickmeeting.ErrServer))
+	})
+
+	t.Run("UnknownJSON", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.GetSessions(context.Background(), 3)
+		var respErr *clickmeeting.ResponseError
+		is.True(errors.As(err, &respErr))
+		is.True(errors.Is(err, clickmeeting.ErrNotFound))
+	})
+}
+
+func Test_FieldErrors(t *testing.T) {
+	is := is.New(t)
+
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /conferences.json": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"code": 422, "name": "Unprocessable Entity", "errors": [
+				{"name": "settings[thank_you_page_url]", "message": "Invalid url"},
+				{"name": "duration", "message": "Invalid duration"},
+				{"name": null, "message": "Something went wrong"}
+			]}`)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	_, err := api.CreateRoom(context.Background(), clickmeeting.NewRoom{Name: "Testing"})
+	is.True(errors.Is(err, clickmeeting.ErrValidation))
+
+	var apiErr clickmeeting.APIError
+	is.True(errors.As(err, &apiErr))
+	is.Equal(len(apiErr.Errors), 3)
+
+	fe, ok := apiErr.Field("settings[thank_you_page_url]")
+	is.True(ok)
+	is.Equal(fe.Message, "Invalid url")
+	create, update := fe.Options()
+	is.Equal(create, "WithRoomSettings")
+	is.Equal(update, "SetRoomSettings")
+
+	create, update = apiErr.Errors[1].Options()
+	is.Equal(create, "WithDuration")
+	is.Equal(update, "SetDuration")
+
+	is.Equal(apiErr.Errors[2].Field, "")
+	is.Equal(apiErr.Errors[2].Error(), "Something went wrong")
+}
@@ -0,0 +1,96 @@
+package clickmeeting
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FieldError is a validation error of a single form field.
+type FieldError struct {
+	//Field is the form key that was rejected, e.g. "duration" or "settings[thank_you_page_url]".
+	Field   string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name    json.RawMessage `json:"name"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Message = raw.Message
+	e.Field = ""
+	if len(raw.Name) == 0 || string(raw.Name) == "null" {
+		return nil
+	}
+	// The API usually sends field name as a string, but not always.
+	if err := json.Unmarshal(raw.Name, &e.Field); err != nil {
+		e.Field = string(raw.Name)
+	}
+	e.Field = normalizeFieldKey(e.Field)
+	return nil
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Options returns names of the CreateRoomOption and UpdateRoomOption that set the field.
+// Fields set through NewRoom are reported as e.g. "NewRoom.Name". Empty string means no such option exists.
+// Settings are reported as set by WithRoomSettings and SetRoomSettings, although the patch options set them as well.
+func (e FieldError) Options() (create string, update string) {
+	o := fieldOptions[e.Field]
+	return o.create, o.update
+}
+
+// normalizeFieldKey converts "settings.thank_you_page_url" into form key "settings[thank_you_page_url]".
+func normalizeFieldKey(key string) string {
+	parts := strings.Split(key, ".")
+	if len(parts) == 1 {
+		return key
+	}
+	return parts[0] + "[" + strings.Join(parts[1:], "][") + "]"
+}
+
+type fieldOption struct {
+	create string
+	update string
+}
+
+// fieldOptions maps form keys to options setting them.
+var fieldOptions = map[string]fieldOption{
+	"name":              {"NewRoom.Name", "SetName"},
+	"room_type":         {"NewRoom.RoomType", "SetRoomType"},
+	"permanent_room":    {"NewRoom.PermanentRoom", "SetPermanence"},
+	"access_type":       {"NewRoom.AccessType", "SetAccessType"},
+	"password":          {"WithPassword", "SetPassword"},
+	"duration":          {"WithDuration", "SetDuration"},
+	"lobby_enabled":     {"WithLobby", "SetLobby"},
+	"lobby_description": {"WithLobby", "SetLobby"},
+	"starts_at":         {"WithStartsAt", "SetStartsAt"},
+	"status":            {"", "SetStatus"},
+	"timezone":          {"WithTimezone", "SetTimezone"},
+	"skin_id":           {"WithSkin", "SetSkin"},
+	"description":       {"WithDescription", "SetDescription"},
+
+	"custom_room_url_name": {"WithCustomRoomURL", "SetCustomRoomURL"},
+
+	"registration[enabled]":  {"WithRegistration", ""},
+	"registration[template]": {"WithRegistrationAndTemplate", ""},
+
+	"settings[show_on_personal_page]":        {"WithRoomSettings", "SetRoomSettings"},
+	"settings[thank_you_emails_enabled]":     {"WithRoomSettings", "SetRoomSettings"},
+	"settings[connection_tester_enabled]":    {"WithRoomSettings", "SetRoomSettings"},
+	"settings[phonegateway_enabled]":         {"WithRoomSettings", "SetRoomSettings"},
+	"settings[recorder_autostart_enabled]":   {"WithRoomSettings", "SetRoomSettings"},
+	"settings[room_invite_button_enabled]":   {"WithRoomSettings", "SetRoomSettings"},
+	"settings[social_media_sharing_enabled]": {"WithRoomSettings", "SetRoomSettings"},
+	"settings[connection_status_enabled]":    {"WithRoomSettings", "SetRoomSettings"},
+	"settings[thank_you_page_url]":           {"WithRoomSettings", "SetRoomSettings"},
+}
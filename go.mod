@@ -1,8 +1,11 @@
 module github.com/IAmRadek/clickmeeting.go
 
-go 1.17
+go 1.21
 
-require github.com/matryer/is v1.4.0
+require (
+	github.com/matryer/is v1.4.0
+	gopkg.in/yaml.v2 v2.4.0
+)
 
 require (
 	github.com/Microsoft/go-winio v0.5.0 // indirect
@@ -51,6 +54,5 @@ require (
 	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/ini.v1 v1.63.2 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 	mvdan.cc/xurls/v2 v2.3.0 // indirect
 )
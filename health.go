@@ -0,0 +1,34 @@
+package clickmeeting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HealthHandler returns handler responding with 200 when client.Ping succeeds within timeout,
+// and with 503 otherwise. It is meant to be used as readiness probe. Zero timeout means no limit
+// other than the one of the incoming request. The response never includes the error,
+// onError, when not nil, is called with it instead, e.g. to log it.
+func HealthHandler(client Client, timeout time.Duration, onError func(error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := client.Ping(ctx); err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, "clickmeeting unavailable")
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+}
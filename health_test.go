@@ -0,0 +1,149 @@
+package clickmeeting_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_Ping(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /ping.json": respond(`{"ping": "pong"}`),
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		is := is.New(t)
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+		is.NoErr(api.Ping(context.Background()))
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		is := is.New(t)
+
+		api := clickmeeting.NewAPIWithOptions("wrong", clickmeeting.WithBaseURL(srv.URL))
+		err := api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrInvalidAPIKey))
+		is.True(errors.Is(err, clickmeeting.ErrUnauthorized))
+	})
+
+	t.Run("GatewayError", func(t *testing.T) {
+		is := is.New(t)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "<html><body><h1>502 Bad Gateway</h1></body></html>")
+		}))
+		defer srv.Close()
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL), clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{MaxAttempts: 1}))
+		err := api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrUnreachable))
+		is.True(errors.Is(err, clickmeeting.ErrServer))
+	})
+
+	t.Run("OtherAPIError", func(t *testing.T) {
+		is := is.New(t)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code": 400, "name": "Bad Request", "errors": []}`)
+		}))
+		defer srv.Close()
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+		err := api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrValidation))
+		is.True(errors.Is(err, clickmeeting.ErrUnreachable))
+	})
+
+	t.Run("WrongRegion", func(t *testing.T) {
+		is := is.New(t)
+
+		other := newTestServer(t, map[string]http.HandlerFunc{
+			"GET /ping.json": respond(`{"ping": "pong"}`),
+		})
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(other.URL), clickmeeting.WithRegions(srv.URL))
+		is.NoErr(api.Ping(context.Background()))
+
+		api = clickmeeting.NewAPIWithOptions("wrong", clickmeeting.WithBaseURL(other.URL), clickmeeting.WithRegions(srv.URL))
+		err := api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrInvalidAPIKey)) // rejected in every region
+
+		region := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Api-Key") != "wrong" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			respond(`{"ping": "pong"}`)(w, r)
+		}))
+		defer region.Close()
+
+		api = clickmeeting.NewAPIWithOptions("wrong", clickmeeting.WithBaseURL(srv.URL), clickmeeting.WithRegions(region.URL))
+		err = api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrWrongRegion))
+		is.True(errors.Is(err, clickmeeting.ErrUnauthorized))
+		is.True(!errors.Is(err, clickmeeting.ErrInvalidAPIKey))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		is := is.New(t)
+
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer slow.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(slow.URL))
+		err := api.Ping(ctx)
+		is.True(errors.Is(err, clickmeeting.ErrUnreachable))
+		is.True(errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		is := is.New(t)
+
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(closed.URL), clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{}))
+		err := api.Ping(context.Background())
+		is.True(errors.Is(err, clickmeeting.ErrUnreachable))
+	})
+}
+
+func Test_HealthHandler(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /ping.json": respond(`{"ping": "pong"}`),
+	})
+
+	t.Run("Ready", func(t *testing.T) {
+		is := is.New(t)
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+		rec := httptest.NewRecorder()
+		clickmeeting.HealthHandler(api, time.Second, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		is.Equal(rec.Code, http.StatusOK)
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		is := is.New(t)
+
+		var logged error
+		api := clickmeeting.NewAPIWithOptions("wrong", clickmeeting.WithBaseURL(srv.URL))
+		rec := httptest.NewRecorder()
+		clickmeeting.HealthHandler(api, time.Second, func(err error) { logged = err }).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		is.Equal(rec.Code, http.StatusServiceUnavailable)
+		is.Equal(rec.Body.String(), "clickmeeting unavailable\n")
+		is.True(errors.Is(logged, clickmeeting.ErrInvalidAPIKey))
+	})
+}
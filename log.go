@@ -0,0 +1,78 @@
+package clickmeeting
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Logger receives a record of every request attempt sent by the client.
+type Logger interface {
+	LogRequest(ctx context.Context, entry RequestLog)
+}
+
+// RequestLog describes a single attempt of sending a request.
+type RequestLog struct {
+	Method string
+	Path   string
+	//Status is the HTTP status code of the response, or 0 if no response was received.
+	Status   int
+	Duration time.Duration
+	//Attempt is 1 for the first try and increases with every retry.
+	Attempt int
+	//Header holds request headers, with X-Api-Key redacted.
+	Header http.Header
+	//Err is set when no response was received.
+	Err error
+}
+
+// WithLogger sets logger notified about every request. By default nothing is logged.
+func WithLogger(logger Logger) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.logger = logger
+	}
+}
+
+type noopLogger struct{}
+
+func (noopLogger) LogRequest(context.Context, RequestLog) {}
+
+// NewSlogLogger returns Logger writing to l. Failed requests are logged at warning level, the rest at info level.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l: l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) LogRequest(ctx context.Context, entry RequestLog) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("method", entry.Method),
+		slog.String("path", entry.Path),
+		slog.Int("status", entry.Status),
+		slog.Duration("duration", entry.Duration),
+		slog.Int("attempt", entry.Attempt),
+		slog.Any("header", entry.Header),
+	}
+	if entry.Err != nil {
+		attrs = append(attrs, slog.String("error", entry.Err.Error()))
+	}
+	if entry.Err != nil || entry.Status >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	s.l.LogAttrs(ctx, level, "clickmeeting request", attrs...)
+}
+
+const redacted = "REDACTED"
+
+// redactHeader returns copy of h safe to be logged.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if h.Get("X-Api-Key") != "" {
+		h.Set("X-Api-Key", redacted)
+	}
+	return h
+}
@@ -0,0 +1,69 @@
+package clickmeeting_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+type recordingLogger struct {
+	entries []clickmeeting.RequestLog
+}
+
+func (r *recordingLogger) LogRequest(_ context.Context, entry clickmeeting.RequestLog) {
+	r.entries = append(r.entries, entry)
+}
+
+func Test_Logger(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/active.json": respond(`[]`),
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		is := is.New(t)
+
+		logger := &recordingLogger{}
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithLogger(logger),
+		)
+
+		_, err := api.ListRooms(context.Background(), clickmeeting.ActiveRoom)
+		is.NoErr(err)
+		_ = api.DeleteRoom(context.Background(), 7)
+
+		is.Equal(len(logger.entries), 2)
+		is.Equal(logger.entries[0].Method, http.MethodGet)
+		is.Equal(logger.entries[0].Path, "/conferences/active.json")
+		is.Equal(logger.entries[0].Status, http.StatusOK)
+		is.Equal(logger.entries[0].Attempt, 1)
+		is.Equal(logger.entries[0].Header.Get("X-Api-Key"), "REDACTED")
+		is.Equal(logger.entries[1].Method, http.MethodDelete)
+		is.Equal(logger.entries[1].Status, http.StatusNotFound)
+	})
+
+	t.Run("Slog", func(t *testing.T) {
+		is := is.New(t)
+
+		var buf bytes.Buffer
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithLogger(clickmeeting.NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))),
+		)
+
+		_, err := api.ListRooms(context.Background(), clickmeeting.ActiveRoom)
+		is.NoErr(err)
+
+		out := buf.String()
+		is.True(strings.Contains(out, `"method":"GET"`))
+		is.True(strings.Contains(out, `"status":200`))
+		is.True(strings.Contains(out, `"attempt":1`))
+		is.True(!strings.Contains(out, `"key"`))
+	})
+}
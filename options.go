@@ -5,34 +5,40 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+	// Embedded time zone database lets start time be converted even where the system one is missing.
+	_ "time/tzdata"
 )
 
 type option func(values url.Values)
 
 type CreateRoomOption option
 
-//WithRoomSettings sets various settings of a conference.
+// WithRoomSettings sets various settings of a conference.
+// All settings are sent, use WithRoomSettingsPatch to send only some of them.
 func WithRoomSettings(s RoomSettings) CreateRoomOption {
-	boolToString := func(b bool) string {
-		if b {
-			return "1"
-		}
-		return "0"
-	}
+	return WithRoomSettingsPatch(s.Patch())
+}
+
+// WithRoomSettingsPatch sets settings of a conference that are not nil in p.
+func WithRoomSettingsPatch(p RoomSettingsPatch) CreateRoomOption {
 	return func(v url.Values) {
-		v.Add("settings[show_on_personal_page]", boolToString(s.ShowOnPersonalPage))
-		v.Add("settings[thank_you_emails_enabled]", boolToString(s.ThankYouEmailsEnabled))
-		v.Add("settings[connection_tester_enabled]", boolToString(s.ConnectionTesterEnabled))
-		v.Add("settings[phonegateway_enabled]", boolToString(s.PhoneGatewayEnabled))
-		v.Add("settings[recorder_autostart_enabled]", boolToString(s.RecorderAutostartEnabled))
-		v.Add("settings[room_invite_button_enabled]", boolToString(s.RoomInviteButtonEnabled))
-		v.Add("settings[social_media_sharing_enabled]", boolToString(s.SocialMediaSharingEnabled))
-		v.Add("settings[connection_status_enabled]", boolToString(s.ConnectionStatusEnabled))
-		v.Add("settings[thank_you_page_url]", s.ThankYouPageUrl)
+		for _, f := range settingFields(&RoomSettings{}, &p) {
+			if *f.patch == nil {
+				continue
+			}
+			if **f.patch {
+				v.Add("settings["+f.key+"]", "1")
+			} else {
+				v.Add("settings["+f.key+"]", "0")
+			}
+		}
+		if p.ThankYouPageUrl != nil {
+			v.Add("settings[thank_you_page_url]", *p.ThankYouPageUrl)
+		}
 	}
 }
 
-//WithLobby enabled lobby and sets description.
+// WithLobby enabled lobby and sets description.
 func WithLobby(enabled bool, description string) CreateRoomOption {
 	return func(v url.Values) {
 		if enabled {
@@ -46,14 +52,14 @@ func WithLobby(enabled bool, description string) CreateRoomOption {
 	}
 }
 
-//WithRegistration enables registration.
+// WithRegistration enables registration.
 func WithRegistration() CreateRoomOption {
 	return func(v url.Values) {
 		v.Add("registration[enabled]", "1")
 	}
 }
 
-//WithRegistrationAndTemplate enables registration and sets meeting registration template.
+// WithRegistrationAndTemplate enables registration and sets meeting registration template.
 // Valid template values: 1 - 3
 func WithRegistrationAndTemplate(template int) CreateRoomOption {
 	return func(v url.Values) {
@@ -62,20 +68,139 @@ func WithRegistrationAndTemplate(template int) CreateRoomOption {
 	}
 }
 
-//WithDuration sets duration of a conference.
+// WithDuration sets duration of a conference.
 func WithDuration(d time.Duration) CreateRoomOption {
 	return func(v url.Values) {
 		v.Add("duration", fmt.Sprintf("%d:%d", int(d.Hours()), int(d.Minutes())%60))
 	}
 }
 
-//WithPassword sets password of a conference.
+// WithPassword sets password of a conference.
 func WithPassword(password string) CreateRoomOption {
 	return func(v url.Values) {
 		v.Add("password", password)
 	}
 }
 
+// WithTimezone sets time zone of a conference. Start time set with WithStartsAt is converted to the time zone.
+// The name is not validated, use TimeZones.WithTimezone to accept only values returned by ListTimeZones.
+func WithTimezone(tz TimeZone) CreateRoomOption {
+	return func(v url.Values) {
+		if startsAt, err := parseStartsAt(v); err == nil {
+			if loc, err := time.LoadLocation(string(tz)); err == nil {
+				v.Set("starts_at", startsAt.In(loc).Format(startsAtLayout))
+			}
+		}
+		v.Set("timezone", string(tz))
+	}
+}
+
+// WithTimezone returns WithTimezone option for the time zone with given name,
+// or error matching ErrNotFound if it is not on the list.
+func (z TimeZones) WithTimezone(name string) (CreateRoomOption, error) {
+	tz, err := z.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	return WithTimezone(tz), nil
+}
+
+// SetTimezone returns SetTimezone option for the time zone with given name,
+// or error matching ErrNotFound if it is not on the list.
+func (z TimeZones) SetTimezone(name string) (UpdateRoomOption, error) {
+	tz, err := z.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	return SetTimezone(tz), nil
+}
+
+// startsAtLayout is the wall-clock format of starts_at, interpreted in the conference time zone.
+const startsAtLayout = "2006-01-02 15:04:05"
+
+// WithStartsAt sets start time of a conference. Unless time zone is set with WithTimezone,
+// the location of t is sent as the time zone, or UTC if it is not a named time zone.
+// CreateRoom and UpdateRoom fail if the time zone is not known, instead of sending the time unconverted.
+func WithStartsAt(t time.Time) CreateRoomOption {
+	return func(v url.Values) {
+		tz := v.Get("timezone")
+		if tz == "" {
+			loc := t.Location()
+			if _, err := time.LoadLocation(loc.String()); err != nil || loc.String() == "" || loc == time.Local {
+				loc = time.UTC
+			}
+			v.Set("timezone", loc.String())
+			t = t.In(loc)
+		} else if loc, err := time.LoadLocation(tz); err == nil {
+			t = t.In(loc)
+		}
+		v.Set("starts_at", t.Format(startsAtLayout))
+	}
+}
+
+// checkStartsAt rejects start time that can't be converted to the time zone sent with it,
+// as it would be labelled with a time zone it is not in.
+func checkStartsAt(v url.Values) error {
+	if v.Get("starts_at") == "" {
+		return nil
+	}
+	if _, err := parseStartsAt(v); err != nil {
+		return fmt.Errorf("invalid time zone %q for start time: %w", v.Get("timezone"), err)
+	}
+	return nil
+}
+
+// parseStartsAt returns start time already set in v.
+func parseStartsAt(v url.Values) (time.Time, error) {
+	loc, err := time.LoadLocation(v.Get("timezone"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(startsAtLayout, v.Get("starts_at"), loc)
+}
+
+// WithDescription sets description of a conference.
+func WithDescription(description string) CreateRoomOption {
+	return func(v url.Values) {
+		v.Add("description", description)
+	}
+}
+
+// WithCustomRoomURL sets name used in room url instead of one generated from room name.
+func WithCustomRoomURL(name string) CreateRoomOption {
+	return func(v url.Values) {
+		v.Add("custom_room_url_name", name)
+	}
+}
+
+// WithSkin sets visual skin of a conference room. Only skin.ID is sent and it is not validated,
+// use Skins.WithSkin to accept only skins returned by ListSkins.
+func WithSkin(skin Skin) CreateRoomOption {
+	return func(v url.Values) {
+		v.Add("skin_id", strconv.Itoa(skin.ID))
+	}
+}
+
+// WithSkin returns WithSkin option for the skin with given name,
+// or error matching ErrNotFound if it is not on the list.
+func (s Skins) WithSkin(name string) (CreateRoomOption, error) {
+	skin, err := s.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	return WithSkin(skin), nil
+}
+
+// SetSkin returns SetSkin option for the skin with given name,
+// or error matching ErrNotFound if it is not on the list.
+func (s Skins) SetSkin(name string) (UpdateRoomOption, error) {
+	skin, err := s.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	return SetSkin(skin), nil
+}
+
 type UpdateRoomOption option
 
 func SetName(name string) UpdateRoomOption {
@@ -112,9 +237,24 @@ func SetDuration(d time.Duration) UpdateRoomOption {
 		WithDuration(d)(v)
 	}
 }
-func SetStartsAt(t time.Time) UpdateRoomOption {
+
+// SetStartsAt sets start time of a conference as wall-clock time in tz, which is sent as well,
+// because the API interprets starts_at in the time zone given with it.
+// Pass TimeZone(room.Timezone) to keep the time zone of the room unchanged.
+func SetStartsAt(t time.Time, tz TimeZone) UpdateRoomOption {
+	return func(v url.Values) {
+		WithTimezone(tz)(v)
+		WithStartsAt(t)(v)
+	}
+}
+func SetDescription(description string) UpdateRoomOption {
 	return func(v url.Values) {
-		v.Add("starts_at", t.Format(time.RFC3339))
+		WithDescription(description)(v)
+	}
+}
+func SetCustomRoomURL(name string) UpdateRoomOption {
+	return func(v url.Values) {
+		WithCustomRoomURL(name)(v)
 	}
 }
 
@@ -124,16 +264,34 @@ func SetPassword(password string) UpdateRoomOption {
 		WithPassword(password)(v)
 	}
 }
+func SetTimezone(tz TimeZone) UpdateRoomOption {
+	return func(v url.Values) {
+		WithTimezone(tz)(v)
+	}
+}
+func SetSkin(skin Skin) UpdateRoomOption {
+	return func(v url.Values) {
+		WithSkin(skin)(v)
+	}
+}
 func SetStatus(status RoomStatus) UpdateRoomOption {
 	return func(v url.Values) {
 		v.Add("status", string(status))
 	}
 }
+
+// SetRoomSettings overwrites all settings of a conference, including ones left at zero value.
+// Use SetRoomSettingsPatch or ModifyRoomSettings to change only some of them.
 func SetRoomSettings(settings RoomSettings) UpdateRoomOption {
 	return func(v url.Values) {
 		WithRoomSettings(settings)(v)
 	}
 }
+func SetRoomSettingsPatch(p RoomSettingsPatch) UpdateRoomOption {
+	return func(v url.Values) {
+		WithRoomSettingsPatch(p)(v)
+	}
+}
 
 type SendInvitationOption option
 
@@ -171,3 +329,49 @@ func WithEmailConfirmation(language string) RegisterParticipantOption {
 		values.Add("confirmation_email[lang]", language)
 	}
 }
+
+type AutoLoginOption option
+
+// WithLoginEmail sets email address of the user that will be logged in.
+func WithLoginEmail(email string) AutoLoginOption {
+	return func(v url.Values) {
+		v.Add("email", email)
+	}
+}
+
+// WithLoginNickname sets name displayed in the room.
+func WithLoginNickname(nickname string) AutoLoginOption {
+	return func(v url.Values) {
+		v.Add("nickname", nickname)
+	}
+}
+
+// AutoLoginRole is a role of the user logged in with AutoLoginHash. Unlike InviteeRole, it includes host.
+type AutoLoginRole string
+
+const (
+	LoginAsListener  AutoLoginRole = "listener"
+	LoginAsPresenter AutoLoginRole = "presenter"
+	LoginAsHost      AutoLoginRole = "host"
+)
+
+// WithLoginRole sets role of the user in the room.
+func WithLoginRole(role AutoLoginRole) AutoLoginOption {
+	return func(v url.Values) {
+		v.Add("role", string(role))
+	}
+}
+
+// WithLoginPassword sets password of a PasswordProtected room.
+func WithLoginPassword(password string) AutoLoginOption {
+	return func(v url.Values) {
+		v.Add("password", password)
+	}
+}
+
+// WithLoginToken sets access token of a TokenProtected room.
+func WithLoginToken(token string) AutoLoginOption {
+	return func(v url.Values) {
+		v.Add("token", token)
+	}
+}
@@ -0,0 +1,64 @@
+package clickmeeting_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_RateLimit(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/active.json": respond(`[]`),
+	})
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL),
+		clickmeeting.WithRateLimit(50, 2),
+	)
+
+	t.Run("Concurrent", func(t *testing.T) {
+		is := is.New(t)
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		errs := make(chan error, 6)
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := api.ListRooms(context.Background(), clickmeeting.ActiveRoom)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			is.NoErr(err)
+		}
+		// Burst of 2 goes through immediately, remaining 4 requests are spaced by 20ms.
+		is.True(time.Since(start) >= 70*time.Millisecond)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		is := is.New(t)
+
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithRateLimit(0.1, 1),
+		)
+		_, err := api.ListRooms(context.Background(), clickmeeting.ActiveRoom)
+		is.NoErr(err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = api.ListRooms(ctx, clickmeeting.ActiveRoom)
+		is.True(errors.Is(err, context.DeadlineExceeded))
+	})
+}
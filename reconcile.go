@@ -0,0 +1,193 @@
+package clickmeeting
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DesiredRoom describes how a room should look like. Zero and nil fields are not managed,
+// so the room keeps whatever value it has.
+type DesiredRoom struct {
+	Name       string
+	RoomType   RoomType
+	AccessType AccessType
+	// Password is sent whenever AccessType changes to PasswordProtected.
+	// It can't be read back, so it is not compared with the current one.
+	Password      string
+	PermanentRoom *bool
+	Status        RoomStatus
+
+	LobbyEnabled     *bool
+	LobbyDescription string
+
+	Description string
+	Timezone    TimeZone
+	StartsAt    time.Time
+	Duration    time.Duration
+
+	Settings RoomSettingsPatch
+}
+
+// RoomChange is a single field that differs between current and desired room.
+type RoomChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+func (c RoomChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.From, c.To)
+}
+
+// RoomDiff lists changes needed to turn the room into the desired one.
+type RoomDiff struct {
+	RoomID  int
+	Changes []RoomChange
+
+	opts []UpdateRoomOption
+}
+
+func (d RoomDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Options returns the smallest set of options applying the diff with UpdateRoom.
+func (d RoomDiff) Options() []UpdateRoomOption {
+	return d.opts
+}
+
+func (d RoomDiff) String() string {
+	if d.IsEmpty() {
+		return fmt.Sprintf("room %d: no changes", d.RoomID)
+	}
+	lines := make([]string, 0, len(d.Changes)+1)
+	lines = append(lines, fmt.Sprintf("room %d:", d.RoomID))
+	for _, c := range d.Changes {
+		lines = append(lines, "  "+c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares current room with desired state.
+func (d DesiredRoom) Diff(current Room) RoomDiff {
+	diff := RoomDiff{RoomID: current.ID}
+	change := func(field string, from, to interface{}, opt UpdateRoomOption) {
+		diff.Changes = append(diff.Changes, RoomChange{Field: field, From: formatValue(from), To: formatValue(to)})
+		if opt != nil {
+			diff.opts = append(diff.opts, opt)
+		}
+	}
+
+	if d.Name != "" && d.Name != current.Name {
+		change("name", current.Name, d.Name, SetName(d.Name))
+	}
+	if d.RoomType != "" && d.RoomType != current.RoomType {
+		change("room_type", current.RoomType, d.RoomType, SetRoomType(d.RoomType))
+	}
+	if d.AccessType != UnknownType && d.AccessType != current.AccessType {
+		opt := SetAccessType(d.AccessType)
+		if d.AccessType == PasswordProtected && d.Password != "" {
+			opt = SetPassword(d.Password)
+		}
+		change("access_type", current.AccessType, d.AccessType, opt)
+	}
+	if d.PermanentRoom != nil && *d.PermanentRoom != current.PermanentRoom {
+		change("permanent_room", current.PermanentRoom, *d.PermanentRoom, SetPermanence(*d.PermanentRoom))
+	}
+	if d.Status != "" && d.Status != current.Status {
+		change("status", current.Status, d.Status, SetStatus(d.Status))
+	}
+
+	lobbyEnabled := current.LobbyEnabled
+	if d.LobbyEnabled != nil && *d.LobbyEnabled != current.LobbyEnabled {
+		lobbyEnabled = *d.LobbyEnabled
+		change("lobby_enabled", current.LobbyEnabled, lobbyEnabled, nil)
+	}
+	lobbyDescription := current.LobbyDescription
+	if d.LobbyDescription != "" && d.LobbyDescription != current.LobbyDescription {
+		lobbyDescription = d.LobbyDescription
+		change("lobby_description", current.LobbyDescription, lobbyDescription, nil)
+	}
+	if lobbyEnabled != current.LobbyEnabled || lobbyDescription != current.LobbyDescription {
+		diff.opts = append(diff.opts, SetLobby(lobbyEnabled, lobbyDescription))
+	}
+
+	if d.Description != "" && d.Description != current.Description {
+		change("description", current.Description, d.Description, SetDescription(d.Description))
+	}
+	tz := TimeZone(current.Timezone)
+	tzChanged := d.Timezone != "" && string(d.Timezone) != current.Timezone
+	if tzChanged {
+		tz = d.Timezone
+		change("timezone", current.Timezone, d.Timezone, SetTimezone(d.Timezone))
+	}
+	if tz == "" {
+		tz = "UTC"
+	}
+	switch {
+	case !d.StartsAt.IsZero() && !d.StartsAt.Equal(current.StartsAt):
+		change("starts_at", formatTime(current.StartsAt), formatTime(d.StartsAt), SetStartsAt(d.StartsAt, tz))
+	case tzChanged && !current.StartsAt.IsZero():
+		// The API reads starts_at in the time zone sent with it, so it is sent again
+		// to keep the meeting at the same moment.
+		diff.opts = append(diff.opts, SetStartsAt(current.StartsAt, tz))
+	}
+	if d.Duration != 0 && d.Duration.Truncate(time.Minute) != current.Duration.Truncate(time.Minute) {
+		change("duration", current.Duration, d.Duration, SetDuration(d.Duration))
+	}
+
+	for _, f := range settingFields(&current.Settings, &d.Settings) {
+		if *f.patch != nil && **f.patch != *f.value {
+			change("settings["+f.key+"]", *f.value, **f.patch, nil)
+		}
+	}
+	if d.Settings.ThankYouPageUrl != nil && *d.Settings.ThankYouPageUrl != current.Settings.ThankYouPageUrl {
+		change("settings[thank_you_page_url]", current.Settings.ThankYouPageUrl, *d.Settings.ThankYouPageUrl, nil)
+	}
+	settings := current.Settings.Diff(d.Settings.Apply(current.Settings))
+	if !settings.IsEmpty() {
+		diff.opts = append(diff.opts, SetRoomSettingsPatch(settings))
+	}
+
+	return diff
+}
+
+// formatValue quotes strings, so that empty values are visible in the diff.
+func formatValue(v interface{}) string {
+	if reflect.ValueOf(v).Kind() == reflect.String {
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Reconcile fetches the room and updates fields that differ from desired state. Returned diff
+// describes the changes made.
+func (api *api) Reconcile(ctx context.Context, roomID int, desired DesiredRoom) (RoomDiff, error) {
+	diff, err := api.PlanReconcile(ctx, roomID, desired)
+	if err != nil || diff.IsEmpty() {
+		return diff, err
+	}
+	if _, err := api.UpdateRoom(ctx, roomID, diff.Options()...); err != nil {
+		return diff, fmt.Errorf("failed to update room %d: %w", roomID, err)
+	}
+	return diff, nil
+}
+
+// PlanReconcile returns changes Reconcile would make, without applying them.
+func (api *api) PlanReconcile(ctx context.Context, roomID int, desired DesiredRoom) (RoomDiff, error) {
+	room, err := api.GetRoom(ctx, roomID)
+	if err != nil {
+		return RoomDiff{}, err
+	}
+	return desired.Diff(room), nil
+}
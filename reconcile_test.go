@@ -0,0 +1,140 @@
+package clickmeeting_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_Reconcile(t *testing.T) {
+	var (
+		form    url.Values
+		updates int
+	)
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7.json": respond(`{"conference": {
+			"id": 7, "name": "Weekly", "room_type": "meeting", "access_type": 1, "timezone": "Europe/Warsaw",
+			"lobby_enabled": false, "lobby_description": "Wait here", "duration": "1:00",
+			"settings": {"show_on_personal_page": true, "recorder_autostart_enabled": false}
+		}}`),
+		"PUT /conferences/7.json": func(w http.ResponseWriter, r *http.Request) {
+			updates++
+			r.ParseForm()
+			form = r.PostForm
+			respond(`{"conference": {"id": 7}}`)(w, r)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+
+	on, off := true, false
+	desired := clickmeeting.DesiredRoom{
+		Name:         "Weekly sync",
+		RoomType:     clickmeeting.Meeting,
+		AccessType:   clickmeeting.OpenType,
+		LobbyEnabled: &on,
+		Duration:     90 * time.Minute,
+		Settings: clickmeeting.RoomSettingsPatch{
+			ShowOnPersonalPage:       &on,
+			RecorderAutostartEnabled: &on,
+			ConnectionTesterEnabled:  &off,
+		},
+	}
+
+	t.Run("PlanReconcile", func(t *testing.T) {
+		is := is.New(t)
+
+		diff, err := api.PlanReconcile(context.Background(), 7, desired)
+		is.NoErr(err)
+		is.Equal(updates, 0)
+		is.Equal(diff.String(), `room 7:
+  name: "Weekly" -> "Weekly sync"
+  lobby_enabled: false -> true
+  duration: 1h0m0s -> 1h30m0s
+  settings[recorder_autostart_enabled]: false -> true`)
+	})
+
+	t.Run("Reconcile", func(t *testing.T) {
+		is := is.New(t)
+
+		diff, err := api.Reconcile(context.Background(), 7, desired)
+		is.NoErr(err)
+		is.Equal(len(diff.Changes), 4)
+		is.Equal(updates, 1)
+		is.Equal(form, url.Values{
+			"name":                                 {"Weekly sync"},
+			"lobby_enabled":                        {"1"},
+			"lobby_description":                    {"Wait here"},
+			"duration":                             {"1:30"},
+			"settings[recorder_autostart_enabled]": {"1"},
+		})
+	})
+
+	t.Run("Password", func(t *testing.T) {
+		is := is.New(t)
+
+		diff, err := api.Reconcile(context.Background(), 7, clickmeeting.DesiredRoom{
+			AccessType: clickmeeting.PasswordProtected,
+			Password:   "secret",
+		})
+		is.NoErr(err)
+		is.Equal(diff.String(), `room 7:
+  access_type: OpenType -> PasswordProtected`)
+		is.Equal(form, url.Values{
+			"access_type": {"2"},
+			"password":    {"secret"},
+		})
+	})
+
+	t.Run("KeepsTimezone", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := api.Reconcile(context.Background(), 7, clickmeeting.DesiredRoom{
+			StartsAt: time.Date(2021, 10, 11, 8, 0, 0, 0, time.UTC),
+		})
+		is.NoErr(err)
+		is.Equal(form, url.Values{
+			"starts_at": {"2021-10-11 10:00:00"},
+			"timezone":  {"Europe/Warsaw"},
+		})
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		is := is.New(t)
+		updates = 0
+
+		diff, err := api.Reconcile(context.Background(), 7, clickmeeting.DesiredRoom{Name: "Weekly"})
+		is.NoErr(err)
+		is.True(diff.IsEmpty())
+		is.Equal(updates, 0)
+	})
+}
+
+func Test_DesiredRoomTimezone(t *testing.T) {
+	is := is.New(t)
+
+	startsAt := time.Date(2021, 10, 11, 8, 0, 0, 0, time.UTC)
+	current := clickmeeting.Room{ID: 7, Timezone: "Europe/Warsaw", StartsAt: startsAt}
+
+	for _, d := range []clickmeeting.DesiredRoom{
+		{Timezone: "Europe/London"},
+		{Timezone: "Europe/London", StartsAt: startsAt},
+	} {
+		diff := d.Diff(current)
+		is.Equal(diff.String(), `room 7:
+  timezone: "Europe/Warsaw" -> "Europe/London"`)
+
+		form := url.Values{}
+		for _, opt := range diff.Options() {
+			opt(form)
+		}
+		is.Equal(form, url.Values{
+			"starts_at": {"2021-10-11 09:00:00"}, // same moment in the new time zone
+			"timezone":  {"Europe/London"},
+		})
+	}
+}
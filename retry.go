@@ -0,0 +1,112 @@
+package clickmeeting
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests failing with a transient error are retried.
+type RetryPolicy struct {
+	//MaxAttempts is the total number of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	//MinBackoff is the delay before the first retry. It doubles with every subsequent attempt.
+	MinBackoff time.Duration
+	//MaxBackoff caps the delay computed from MinBackoff, zero means no cap. Retry-After sent by the server is honored even if longer.
+	MaxBackoff time.Duration
+	//RetryPost enables retrying POST requests, which are not idempotent and might be applied twice.
+	RetryPost bool
+}
+
+// DefaultRetryPolicy retries idempotent requests up to two times.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// WithRetryPolicy sets how requests rejected with 429 or 5xx status, or failed due to network error, are retried.
+// DefaultRetryPolicy is used unless set, pass zero RetryPolicy to disable retries.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry = policy
+	}
+}
+
+// canRetry reports whether req may be sent again after given attempt failed.
+func (p RetryPolicy) canRetry(req *http.Request, attempt int) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPost
+	}
+	return false
+}
+
+// backoff returns jittered delay before the attempt following given one.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d > 0; i++ {
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+		if d > math.MaxInt64/2 {
+			d = math.MaxInt64
+			break
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfter parses Retry-After header given either in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
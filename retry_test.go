@@ -0,0 +1,159 @@
+package clickmeeting_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_Retry(t *testing.T) {
+	var calls int
+	failTwice := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"code": 502, "name": "Bad Gateway", "errors": []}`)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"code": 429, "name": "Too Many Requests", "errors": []}`)
+		default:
+			respond(`{"access_tokens": [{"token": "SS6RG6"}], "room": {"id": 1}}`)(w, r)
+		}
+	}
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7/tokens.json":  failTwice,
+		"POST /conferences/7/tokens.json": failTwice,
+		"POST /conferences.json":          failTwice,
+		"DELETE /conferences/7.json": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code": 404, "name": "Not Found", "errors": []}`)
+		},
+	})
+	policy := clickmeeting.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Hour,
+	}
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL),
+		clickmeeting.WithRetryPolicy(policy),
+	)
+
+	t.Run("RetriesIdempotent", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		tokens, err := api.GetAccessTokens(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(len(tokens), 1)
+		is.Equal(calls, 3)
+	})
+
+	t.Run("SkipsPostByDefault", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		_, err := api.GenerateAccessTokens(context.Background(), 7, 1)
+		var apiErr clickmeeting.APIError
+		is.True(errors.As(err, &apiErr))
+		is.Equal(apiErr.Code, http.StatusBadGateway)
+		is.Equal(calls, 1)
+	})
+
+	t.Run("RetriesPostWhenEnabled", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		policy := policy
+		policy.RetryPost = true
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithRetryPolicy(policy),
+		)
+
+		room, err := api.CreateRoom(context.Background(), clickmeeting.NewRoom{Name: "Testing"})
+		is.NoErr(err)
+		is.Equal(room.ID, 1)
+		is.Equal(calls, 3)
+	})
+
+	t.Run("RetriesByDefault", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		api := clickmeeting.NewAPIWithOptions("key", clickmeeting.WithBaseURL(srv.URL))
+		_, err := api.GetAccessTokens(context.Background(), 7)
+		is.NoErr(err)
+		is.Equal(calls, 3)
+	})
+
+	t.Run("DisabledWithZeroPolicy", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{}),
+		)
+		_, err := api.GetAccessTokens(context.Background(), 7)
+		is.True(errors.Is(err, clickmeeting.ErrServer))
+		is.Equal(calls, 1)
+	})
+
+	t.Run("SkipsClientErrors", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		err := api.DeleteRoom(context.Background(), 7)
+		is.True(err != nil)
+		is.Equal(calls, 1)
+	})
+
+	t.Run("StopsOnContextDone", func(t *testing.T) {
+		is := is.New(t)
+		calls = 0
+
+		api := clickmeeting.NewAPIWithOptions("key",
+			clickmeeting.WithBaseURL(srv.URL),
+			clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour, MaxBackoff: time.Hour}),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := api.GetAccessTokens(ctx, 7)
+		is.True(errors.Is(err, context.DeadlineExceeded))
+		is.Equal(calls, 1)
+	})
+}
+
+func Test_RetryBackoffWithoutCap(t *testing.T) {
+	is := is.New(t)
+
+	var calls int
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /conferences/7/tokens.json": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	})
+	api := clickmeeting.NewAPIWithOptions("key",
+		clickmeeting.WithBaseURL(srv.URL),
+		clickmeeting.WithRetryPolicy(clickmeeting.RetryPolicy{MaxAttempts: 4, MinBackoff: 20 * time.Millisecond}),
+	)
+
+	start := time.Now()
+	_, err := api.GetAccessTokens(context.Background(), 7)
+	is.True(errors.Is(err, clickmeeting.ErrServer))
+	is.Equal(calls, 4)
+	// Jitter keeps at least half of 20, 40 and 80ms, constant backoff would wait at most 60ms.
+	is.True(time.Since(start) >= 70*time.Millisecond)
+}
@@ -1,6 +1,13 @@
 package clickmeeting
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type NewRoom struct {
 	//Name of the room that will be visible to attendees. This name will be part of your meeting room url.
@@ -47,6 +54,122 @@ type Room struct {
 	RecorderList []string     `json:"recorder_list"`
 	WidgetsHash  string       `json:"widgets_hash"`
 	Settings     RoomSettings `json:"settings"`
+
+	Description string `json:"description"`
+	//StartsAt and EndsAt are expressed in the room Timezone when it is known.
+	StartsAt     time.Time        `json:"-"`
+	EndsAt       time.Time        `json:"-"`
+	Duration     time.Duration    `json:"-"`
+	Registration RoomRegistration `json:"registration"`
+
+	//Extra holds fields returned by the API that Room does not know about.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+type RoomRegistration struct {
+	Enabled  bool `json:"enabled"`
+	Template int  `json:"template"`
+}
+
+func (r *Room) UnmarshalJSON(data []byte) error {
+	type room Room
+	var raw struct {
+		room
+		StartsAt string      `json:"starts_at"`
+		EndsAt   string      `json:"ends_at"`
+		Duration interface{} `json:"duration"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = Room(raw.room)
+
+	loc := time.UTC
+	if r.Timezone != "" {
+		if l, err := time.LoadLocation(r.Timezone); err == nil {
+			loc = l
+		}
+	}
+	var err error
+	if r.StartsAt, err = parseRoomTime(raw.StartsAt, loc); err != nil {
+		return fmt.Errorf("invalid starts_at: %w", err)
+	}
+	if r.EndsAt, err = parseRoomTime(raw.EndsAt, loc); err != nil {
+		return fmt.Errorf("invalid ends_at: %w", err)
+	}
+	if r.Duration, err = parseRoomDuration(raw.Duration); err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key := range roomFields {
+		delete(fields, key)
+	}
+	r.Extra = nil
+	if len(fields) > 0 {
+		r.Extra = fields
+	}
+	return nil
+}
+
+// roomFields lists keys of the room JSON decoded into Room fields.
+var roomFields = func() map[string]bool {
+	fields := map[string]bool{"starts_at": true, "ends_at": true, "duration": true}
+	t := reflect.TypeOf(Room{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// parseRoomTime parses either RFC 3339 time or wall-clock time in loc, returning it in loc.
+func parseRoomTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, err = time.ParseInLocation(startsAtLayout, s, loc)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// parseRoomDuration parses duration sent as "H:MM" or "H:MM:SS" string, or as number of minutes.
+func parseRoomDuration(v interface{}) (time.Duration, error) {
+	switch d := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return time.Duration(d * float64(time.Minute)), nil
+	case string:
+		if d == "" {
+			return 0, nil
+		}
+		parts := strings.Split(d, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return 0, fmt.Errorf("unexpected format %q", d)
+		}
+		var total time.Duration
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, fmt.Errorf("unexpected format %q", d)
+			}
+			total += time.Duration(n) * units[i]
+		}
+		return total, nil
+	}
+	return 0, fmt.Errorf("unexpected type %T", v)
 }
 
 type AccessType int
@@ -89,7 +212,7 @@ type RoomStatus string
 
 const (
 	ActiveRoom   RoomStatus = "active"
-	InactiveRoom RoomStatus = "active"
+	InactiveRoom RoomStatus = "inactive"
 )
 
 type RoomSettings struct {
@@ -113,10 +236,90 @@ type RoomSettings struct {
 	ThankYouPageUrl string `json:"thank_you_page_url"`
 }
 
+// RoomSettingsPatch holds settings to be changed. Nil fields are left as they are.
+type RoomSettingsPatch struct {
+	ShowOnPersonalPage        *bool
+	ThankYouEmailsEnabled     *bool
+	ConnectionTesterEnabled   *bool
+	PhoneGatewayEnabled       *bool
+	RecorderAutostartEnabled  *bool
+	RoomInviteButtonEnabled   *bool
+	SocialMediaSharingEnabled *bool
+	ConnectionStatusEnabled   *bool
+	ThankYouPageUrl           *string
+}
+
+// Patch returns patch setting every setting to its value in s.
+func (s RoomSettings) Patch() RoomSettingsPatch {
+	var p RoomSettingsPatch
+	for _, f := range settingFields(&s, &p) {
+		v := *f.value
+		*f.patch = &v
+	}
+	url := s.ThankYouPageUrl
+	p.ThankYouPageUrl = &url
+	return p
+}
+
+// Diff returns patch changing s into to, holding only settings that differ.
+func (s RoomSettings) Diff(to RoomSettings) RoomSettingsPatch {
+	var p RoomSettingsPatch
+	from, into := settingFields(&s, &p), settingFields(&to, &p)
+	for i := range from {
+		if *from[i].value != *into[i].value {
+			v := *into[i].value
+			*from[i].patch = &v
+		}
+	}
+	if s.ThankYouPageUrl != to.ThankYouPageUrl {
+		url := to.ThankYouPageUrl
+		p.ThankYouPageUrl = &url
+	}
+	return p
+}
+
+// Apply returns s with settings set in p changed.
+func (p RoomSettingsPatch) Apply(s RoomSettings) RoomSettings {
+	for _, f := range settingFields(&s, &p) {
+		if *f.patch != nil {
+			*f.value = **f.patch
+		}
+	}
+	if p.ThankYouPageUrl != nil {
+		s.ThankYouPageUrl = *p.ThankYouPageUrl
+	}
+	return s
+}
+
+// IsEmpty reports whether p changes no setting.
+func (p RoomSettingsPatch) IsEmpty() bool {
+	return p == RoomSettingsPatch{}
+}
+
+type settingField struct {
+	key   string
+	value *bool
+	patch **bool
+}
+
+// settingFields pairs boolean fields of s with their counterparts in p.
+func settingFields(s *RoomSettings, p *RoomSettingsPatch) []settingField {
+	return []settingField{
+		{"show_on_personal_page", &s.ShowOnPersonalPage, &p.ShowOnPersonalPage},
+		{"thank_you_emails_enabled", &s.ThankYouEmailsEnabled, &p.ThankYouEmailsEnabled},
+		{"connection_tester_enabled", &s.ConnectionTesterEnabled, &p.ConnectionTesterEnabled},
+		{"phonegateway_enabled", &s.PhoneGatewayEnabled, &p.PhoneGatewayEnabled},
+		{"recorder_autostart_enabled", &s.RecorderAutostartEnabled, &p.RecorderAutostartEnabled},
+		{"room_invite_button_enabled", &s.RoomInviteButtonEnabled, &p.RoomInviteButtonEnabled},
+		{"social_media_sharing_enabled", &s.SocialMediaSharingEnabled, &p.SocialMediaSharingEnabled},
+		{"connection_status_enabled", &s.ConnectionStatusEnabled, &p.ConnectionStatusEnabled},
+	}
+}
+
 type AccessToken struct {
 	Token        string     `json:"token"`
 	SentToEmail  string     `json:"sent_to_email,omitempty"`
-	FirstUseData *time.Time `json:"first_use_data,omitempty"`
+	FirstUseData *time.Time `json:"first_use_date,omitempty"`
 }
 
 type Participant struct {
@@ -163,7 +366,142 @@ type Attendee struct {
 	Login     string    `json:"login"`
 }
 
+// ParticipantRole is a role a person had in a session.
+type ParticipantRole string
+
+const (
+	ListenerRole  ParticipantRole = "listener"
+	PresenterRole ParticipantRole = "presenter"
+	HostRole      ParticipantRole = "host"
+)
+
+// SessionParticipant is a person that attended a session, as opposed to Participant that only registered.
+type SessionParticipant struct {
+	ID       int             `json:"id"`
+	Nickname string          `json:"nickname"`
+	Email    string          `json:"email"`
+	Role     ParticipantRole `json:"role"`
+	//Device used to join the session, e.g. "desktop" or "mobile".
+	Device   string    `json:"device"`
+	JoinedAt time.Time `json:"start_date"`
+	LeftAt   time.Time `json:"end_date"`
+}
+
 type PDFSummary struct {
 	URL      string `json:"generate_pdf_url"`
 	Progress int    `json:"progress"`
 }
+
+type Recording struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	//Size of the recording file in bytes.
+	Size      int64         `json:"recording_file_size"`
+	Duration  time.Duration `json:"-"`
+	URL       string        `json:"recording_url"`
+	CreatedAt time.Time     `json:"recorder_started"`
+}
+
+func (r *Recording) UnmarshalJSON(data []byte) error {
+	type recording Recording
+	var raw struct {
+		recording
+		//Duration is sent in seconds.
+		Duration int64 `json:"recording_duration"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = Recording(raw.recording)
+	r.Duration = time.Duration(raw.Duration) * time.Second
+	return nil
+}
+
+// File is a document or presentation stored in the file library.
+type File struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	//Type is the file extension, e.g. "pdf" or "pptx".
+	Type string `json:"type"`
+	//Size of the file in bytes.
+	Size int64 `json:"size"`
+	//Status is "converting" until the file is ready to be shown in the room.
+	Status             string `json:"status"`
+	ConversionProgress int    `json:"conversion_progress"`
+	Pages              int    `json:"document_pages"`
+}
+
+// Chat is an archived chat of a single session.
+type Chat struct {
+	SessionID    int    `json:"id"`
+	Name         string `json:"name"`
+	Date         string `json:"date"`
+	Time         string `json:"time"`
+	DownloadLink string `json:"download_link"`
+}
+
+type ChatMessage struct {
+	Time   time.Time
+	Author string
+	Text   string
+	//Private is set for messages sent directly to Recipient instead of everyone in the room.
+	Private   bool
+	Recipient string
+}
+
+// TimeZone is a time zone name accepted by the API, e.g. "Europe/Warsaw".
+type TimeZone string
+
+type TimeZones []TimeZone
+
+// Find returns time zone with given name, or ErrNotFound if the API does not accept it.
+func (z TimeZones) Find(name string) (TimeZone, error) {
+	for _, tz := range z {
+		if string(tz) == name {
+			return tz, nil
+		}
+	}
+	return "", fmt.Errorf("time zone %q: %w", name, ErrNotFound)
+}
+
+// PhoneGateway is a phone number attendees can dial to join the room using RoomPin.
+type PhoneGateway struct {
+	ID      int    `json:"id"`
+	Country string `json:"country"`
+	City    string `json:"city"`
+	Number  string `json:"number"`
+}
+
+// Skin is a visual theme of the conference room.
+type Skin struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	PreviewURL string `json:"preview"`
+}
+
+type Skins []Skin
+
+// Find returns skin with given name, or ErrNotFound if there is no such skin.
+func (s Skins) Find(name string) (Skin, error) {
+	for _, skin := range s {
+		if skin.Name == name {
+			return skin, nil
+		}
+	}
+	return Skin{}, fmt.Errorf("skin %q: %w", name, ErrNotFound)
+}
+
+// Contact is an entry of the account address book.
+type Contact struct {
+	Email     string `json:"email"`
+	FirstName string `json:"firstname"`
+	LastName  string `json:"lastname"`
+	Phone     string `json:"phone"`
+	Company   string `json:"company"`
+}
+
+// ContactResult reports outcome of importing a single contact.
+type ContactResult struct {
+	Contact Contact
+	Err     error
+}
@@ -0,0 +1,58 @@
+package clickmeeting_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/matryer/is"
+)
+
+func Test_RoomUnmarshal(t *testing.T) {
+	is := is.New(t)
+
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	var room clickmeeting.Room
+	err = json.Unmarshal([]byte(`{
+		"id": 7,
+		"name": "Weekly",
+		"timezone": "Europe/Warsaw",
+		"starts_at": "2021-10-11T08:00:00+00:00",
+		"ends_at": "2021-10-11 11:30:00",
+		"duration": "1:30",
+		"description": "Weekly sync",
+		"registration": {"enabled": true, "template": 2},
+		"settings": {"recorder_autostart_enabled": true},
+		"widgets_hash": "abc",
+		"phone_gateway": {"number": "+48"},
+		"new_flag": true
+	}`), &room)
+	is.NoErr(err)
+
+	is.Equal(room.ID, 7)
+	is.Equal(room.StartsAt, time.Date(2021, 10, 11, 10, 0, 0, 0, warsaw))
+	is.Equal(room.EndsAt, time.Date(2021, 10, 11, 11, 30, 0, 0, warsaw))
+	is.Equal(room.Duration, 90*time.Minute)
+	is.Equal(room.Description, "Weekly sync")
+	is.Equal(room.Registration, clickmeeting.RoomRegistration{Enabled: true, Template: 2})
+	is.True(room.Settings.RecorderAutostartEnabled)
+	is.Equal(len(room.Extra), 2)
+	is.Equal(string(room.Extra["new_flag"]), "true")
+	is.Equal(string(room.Extra["phone_gateway"]), `{"number": "+48"}`)
+}
+
+func Test_RoomUnmarshalMinimal(t *testing.T) {
+	is := is.New(t)
+
+	var room clickmeeting.Room
+	is.NoErr(json.Unmarshal([]byte(`{"id": 1, "duration": null}`), &room))
+	is.Equal(room.ID, 1)
+	is.True(room.StartsAt.IsZero())
+	is.Equal(room.Duration, time.Duration(0))
+	is.Equal(room.Extra, nil)
+}
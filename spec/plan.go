@@ -0,0 +1,206 @@
+package spec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IAmRadek/clickmeeting.go"
+)
+
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+	// Adopt records existing room found by slug in state, updating it if needed.
+	Adopt Action = "adopt"
+)
+
+// Change is a single planned action on a room.
+type Change struct {
+	Action Action
+	Key    string
+	//RoomID is zero for rooms to be created.
+	RoomID int
+	//Room is the declaration of created or updated room.
+	Room Room
+	//Diff lists fields changed by an update.
+	Diff clickmeeting.RoomDiff
+}
+
+func (c Change) String() string {
+	switch c.Action {
+	case Create:
+		return fmt.Sprintf("+ create %s (%q)", c.Key, c.Room.Name)
+	case Update, Adopt:
+		lines := []string{fmt.Sprintf("~ %s %s (room %d)", c.Action, c.Key, c.RoomID)}
+		for _, ch := range c.Diff.Changes {
+			lines = append(lines, "    "+ch.String())
+		}
+		return strings.Join(lines, "\n")
+	case Delete:
+		return fmt.Sprintf("- delete %s (room %d)", c.Key, c.RoomID)
+	}
+	return fmt.Sprintf("? %s %s", c.Action, c.Key)
+}
+
+// Plan lists changes needed to bring existing rooms in line with specs.
+type Plan struct {
+	Changes []Change
+}
+
+func (p Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p Plan) String() string {
+	if p.IsEmpty() {
+		return "No changes."
+	}
+
+	var adopts, creates, updates, deletes int
+	lines := make([]string, 0, len(p.Changes)+1)
+	for _, c := range p.Changes {
+		lines = append(lines, c.String())
+		switch c.Action {
+		case Create:
+			creates++
+		case Update:
+			updates++
+		case Delete:
+			deletes++
+		case Adopt:
+			adopts++
+		}
+	}
+	summary := fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", creates, updates, deletes)
+	if adopts > 0 {
+		summary = fmt.Sprintf("Plan: %d to adopt, %d to create, %d to update, %d to delete.", adopts, creates, updates, deletes)
+	}
+	lines = append(lines, summary)
+	return strings.Join(lines, "\n")
+}
+
+// MakePlan compares rooms with existing ones recorded in state. Rooms missing from state,
+// or whose recorded room no longer exists, adopt existing room with the same slug, or are created
+// if there is none. Rooms recorded in state but no longer declared are deleted. Nil state is treated as empty.
+func MakePlan(ctx context.Context, client clickmeeting.Client, rooms []Room, state *State) (Plan, error) {
+	if err := Validate(rooms); err != nil {
+		return Plan{}, err
+	}
+	if state == nil {
+		state = &State{}
+	}
+
+	existing := map[int]clickmeeting.Room{}
+	bySlug := map[string]clickmeeting.Room{}
+	for _, status := range []clickmeeting.RoomStatus{clickmeeting.ActiveRoom, clickmeeting.InactiveRoom} {
+		list, err := client.ListRooms(ctx, status)
+		if err != nil {
+			return Plan{}, fmt.Errorf("failed to list %s rooms: %w", status, err)
+		}
+		for _, room := range list {
+			existing[room.ID] = room
+			if room.Slug != "" {
+				bySlug[room.Slug] = room
+			}
+		}
+	}
+
+	// Rooms recorded in state are never adopted by another key.
+	managed := make(map[int]bool, len(state.Rooms))
+	for _, id := range state.Rooms {
+		managed[id] = true
+	}
+
+	var plan Plan
+	declared := make(map[string]bool, len(rooms))
+	for _, room := range rooms {
+		declared[room.Key] = true
+
+		action := Update
+		current, ok := existing[state.Rooms[room.Key]]
+		if !ok && room.Slug != "" {
+			current, ok = bySlug[room.Slug]
+			ok = ok && !managed[current.ID]
+			action = Adopt
+		}
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: Create, Key: room.Key, Room: room})
+			continue
+		}
+
+		desired, err := room.desired()
+		if err != nil {
+			return Plan{}, fmt.Errorf("room %q: %w", room.Key, err)
+		}
+		if diff := desired.Diff(current); action == Adopt || !diff.IsEmpty() {
+			plan.Changes = append(plan.Changes, Change{Action: action, Key: room.Key, RoomID: current.ID, Room: room, Diff: diff})
+		}
+	}
+
+	stale := make([]string, 0)
+	for key := range state.Rooms {
+		if !declared[key] {
+			stale = append(stale, key)
+		}
+	}
+	sort.Strings(stale)
+	for _, key := range stale {
+		plan.Changes = append(plan.Changes, Change{Action: Delete, Key: key, RoomID: state.Rooms[key]})
+	}
+
+	return plan, nil
+}
+
+// Apply carries out the plan, recording created, adopted and deleted rooms in state. It stops at the first
+// failure; state then reflects the changes applied so far and should be saved anyway.
+func Apply(ctx context.Context, client clickmeeting.Client, plan Plan, state *State) error {
+	if state == nil {
+		return errors.New("state is required to record applied changes")
+	}
+	if state.Rooms == nil {
+		state.Rooms = map[string]int{}
+	}
+	for _, c := range plan.Changes {
+		if err := apply(ctx, client, c, state); err != nil {
+			return fmt.Errorf("failed to %s %s: %w", c.Action, c.Key, err)
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, client clickmeeting.Client, c Change, state *State) error {
+	switch c.Action {
+	case Create:
+		newRoom, opts, err := c.Room.create()
+		if err != nil {
+			return err
+		}
+		room, err := client.CreateRoom(ctx, newRoom, opts...)
+		if err != nil {
+			return err
+		}
+		state.Rooms[c.Key] = room.ID
+	case Update, Adopt:
+		if !c.Diff.IsEmpty() {
+			if _, err := client.UpdateRoom(ctx, c.RoomID, c.Diff.Options()...); err != nil {
+				return err
+			}
+		}
+		state.Rooms[c.Key] = c.RoomID
+	case Delete:
+		err := client.DeleteRoom(ctx, c.RoomID)
+		if err != nil && !errors.Is(err, clickmeeting.ErrNotFound) {
+			return err
+		}
+		delete(state.Rooms, c.Key)
+	default:
+		return fmt.Errorf("unknown action %q", c.Action)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// Package spec manages ClickMeeting rooms declared in YAML or JSON files.
+//
+// A spec file lists rooms identified by a stable key:
+//
+//	rooms:
+//	  - key: weekly-sync
+//	    slug: weekly-sync
+//	    name: Weekly sync
+//	    type: meeting
+//	    access: password
+//	    password: secret
+//	    lobby:
+//	      enabled: true
+//	      description: We will start shortly
+//	    settings:
+//	      recorder_autostart_enabled: true
+//	    schedule:
+//	      starts_at: 2021-10-11 10:00
+//	      timezone: Europe/Warsaw
+//	      duration: 1h30m
+//
+// MakePlan compares the rooms with existing ones, found through State mapping keys to room ids,
+// or by slug for rooms not in State yet, and Apply carries out the planned creates, updates and deletes.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"gopkg.in/yaml.v2"
+)
+
+// File is the content of a spec file.
+type File struct {
+	Rooms []Room `yaml:"rooms" json:"rooms"`
+}
+
+// Room is a declaration of a single room.
+type Room struct {
+	//Key identifies the room across runs. It must be unique and should never change.
+	Key string `yaml:"key" json:"key"`
+	//Slug is the custom part of the room url. Existing room with the same slug is adopted
+	//instead of creating a new one when the room is not in state.
+	Slug string                `yaml:"slug" json:"slug"`
+	Name string                `yaml:"name" json:"name"`
+	Type clickmeeting.RoomType `yaml:"type" json:"type"`
+	//Access is one of "open", "password" or "token".
+	Access    string `yaml:"access" json:"access"`
+	Password  string `yaml:"password" json:"password"`
+	Permanent bool   `yaml:"permanent" json:"permanent"`
+
+	Lobby    *Lobby    `yaml:"lobby" json:"lobby"`
+	Settings Settings  `yaml:"settings" json:"settings"`
+	Schedule *Schedule `yaml:"schedule" json:"schedule"`
+}
+
+type Lobby struct {
+	Enabled     bool   `yaml:"enabled" json:"enabled"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// Settings of the room. Settings left out are not managed.
+type Settings struct {
+	ShowOnPersonalPage        *bool   `yaml:"show_on_personal_page" json:"show_on_personal_page"`
+	ThankYouEmailsEnabled     *bool   `yaml:"thank_you_emails_enabled" json:"thank_you_emails_enabled"`
+	ConnectionTesterEnabled   *bool   `yaml:"connection_tester_enabled" json:"connection_tester_enabled"`
+	PhoneGatewayEnabled       *bool   `yaml:"phonegateway_enabled" json:"phonegateway_enabled"`
+	RecorderAutostartEnabled  *bool   `yaml:"recorder_autostart_enabled" json:"recorder_autostart_enabled"`
+	RoomInviteButtonEnabled   *bool   `yaml:"room_invite_button_enabled" json:"room_invite_button_enabled"`
+	SocialMediaSharingEnabled *bool   `yaml:"social_media_sharing_enabled" json:"social_media_sharing_enabled"`
+	ConnectionStatusEnabled   *bool   `yaml:"connection_status_enabled" json:"connection_status_enabled"`
+	ThankYouPageURL           *string `yaml:"thank_you_page_url" json:"thank_you_page_url"`
+}
+
+type Schedule struct {
+	//StartsAt is given as "2006-01-02 15:04" in Timezone, or in RFC 3339 format.
+	StartsAt string `yaml:"starts_at" json:"starts_at"`
+	Timezone string `yaml:"timezone" json:"timezone"`
+	//Duration is given in time.ParseDuration format, e.g. "1h30m".
+	Duration string `yaml:"duration" json:"duration"`
+}
+
+// Load reads rooms from a file. Files with .json extension are parsed as JSON, all others as YAML.
+func Load(path string) ([]Room, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var f File
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		err = yaml.UnmarshalStrict(data, &f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := Validate(f.Rooms); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return f.Rooms, nil
+}
+
+// Validate checks that every room has a unique key and can be converted into API requests.
+func Validate(rooms []Room) error {
+	keys := make(map[string]bool, len(rooms))
+	slugs := make(map[string]bool, len(rooms))
+	for i, room := range rooms {
+		if room.Key == "" {
+			return fmt.Errorf("room #%d: missing key", i+1)
+		}
+		if keys[room.Key] {
+			return fmt.Errorf("room %q: duplicate key", room.Key)
+		}
+		keys[room.Key] = true
+		if room.Slug != "" {
+			if slugs[room.Slug] {
+				return fmt.Errorf("room %q: duplicate slug %q", room.Key, room.Slug)
+			}
+			slugs[room.Slug] = true
+		}
+
+		if room.Name == "" {
+			return fmt.Errorf("room %q: missing name", room.Key)
+		}
+		if _, err := room.desired(); err != nil {
+			return fmt.Errorf("room %q: %w", room.Key, err)
+		}
+	}
+	return nil
+}
+
+func (r Room) accessType() (clickmeeting.AccessType, error) {
+	switch r.Access {
+	case "", "open":
+		return clickmeeting.OpenType, nil
+	case "password":
+		if r.Password == "" {
+			return 0, fmt.Errorf("password access requires password")
+		}
+		return clickmeeting.PasswordProtected, nil
+	case "token":
+		return clickmeeting.TokenProtected, nil
+	}
+	return 0, fmt.Errorf("unknown access %q", r.Access)
+}
+
+func (r Room) roomType() (clickmeeting.RoomType, error) {
+	switch r.Type {
+	case "":
+		return clickmeeting.Meeting, nil
+	case clickmeeting.Meeting, clickmeeting.Webinar:
+		return r.Type, nil
+	}
+	return "", fmt.Errorf("unknown type %q", r.Type)
+}
+
+func (s Settings) patch() clickmeeting.RoomSettingsPatch {
+	return clickmeeting.RoomSettingsPatch{
+		ShowOnPersonalPage:        s.ShowOnPersonalPage,
+		ThankYouEmailsEnabled:     s.ThankYouEmailsEnabled,
+		ConnectionTesterEnabled:   s.ConnectionTesterEnabled,
+		PhoneGatewayEnabled:       s.PhoneGatewayEnabled,
+		RecorderAutostartEnabled:  s.RecorderAutostartEnabled,
+		RoomInviteButtonEnabled:   s.RoomInviteButtonEnabled,
+		SocialMediaSharingEnabled: s.SocialMediaSharingEnabled,
+		ConnectionStatusEnabled:   s.ConnectionStatusEnabled,
+		ThankYouPageUrl:           s.ThankYouPageURL,
+	}
+}
+
+const startsAtLayout = "2006-01-02 15:04"
+
+// desired converts r into state expected from an existing room.
+func (r Room) desired() (clickmeeting.DesiredRoom, error) {
+	roomType, err := r.roomType()
+	if err != nil {
+		return clickmeeting.DesiredRoom{}, err
+	}
+	accessType, err := r.accessType()
+	if err != nil {
+		return clickmeeting.DesiredRoom{}, err
+	}
+
+	permanent := r.Permanent
+	d := clickmeeting.DesiredRoom{
+		Name:          r.Name,
+		RoomType:      roomType,
+		AccessType:    accessType,
+		Password:      r.Password,
+		PermanentRoom: &permanent,
+		Settings:      r.Settings.patch(),
+	}
+	if r.Lobby != nil {
+		enabled := r.Lobby.Enabled
+		d.LobbyEnabled = &enabled
+		d.LobbyDescription = r.Lobby.Description
+	}
+
+	if s := r.Schedule; s != nil {
+		loc := time.UTC
+		if s.Timezone != "" {
+			if loc, err = time.LoadLocation(s.Timezone); err != nil {
+				return clickmeeting.DesiredRoom{}, fmt.Errorf("invalid timezone: %w", err)
+			}
+			d.Timezone = clickmeeting.TimeZone(s.Timezone)
+		}
+		if s.StartsAt != "" {
+			d.StartsAt, err = time.ParseInLocation(startsAtLayout, s.StartsAt, loc)
+			if err != nil {
+				d.StartsAt, err = time.Parse(time.RFC3339, s.StartsAt)
+			}
+			if err != nil {
+				return clickmeeting.DesiredRoom{}, fmt.Errorf("invalid starts_at %q", s.StartsAt)
+			}
+			d.StartsAt = d.StartsAt.In(loc)
+		}
+		if s.Duration != "" {
+			if d.Duration, err = time.ParseDuration(s.Duration); err != nil {
+				return clickmeeting.DesiredRoom{}, fmt.Errorf("invalid duration: %w", err)
+			}
+		}
+	}
+	return d, nil
+}
+
+// create converts r into CreateRoom arguments.
+func (r Room) create() (clickmeeting.NewRoom, []clickmeeting.CreateRoomOption, error) {
+	d, err := r.desired()
+	if err != nil {
+		return clickmeeting.NewRoom{}, nil, err
+	}
+
+	newRoom := clickmeeting.NewRoom{
+		Name:          d.Name,
+		RoomType:      d.RoomType,
+		PermanentRoom: r.Permanent,
+		AccessType:    d.AccessType,
+	}
+	var opts []clickmeeting.CreateRoomOption
+	if r.Slug != "" {
+		opts = append(opts, clickmeeting.WithCustomRoomURL(r.Slug))
+	}
+	if r.Password != "" {
+		opts = append(opts, clickmeeting.WithPassword(r.Password))
+	}
+	if r.Lobby != nil {
+		opts = append(opts, clickmeeting.WithLobby(r.Lobby.Enabled, r.Lobby.Description))
+	}
+	if d.Timezone != "" {
+		opts = append(opts, clickmeeting.WithTimezone(d.Timezone))
+	}
+	if !d.StartsAt.IsZero() {
+		opts = append(opts, clickmeeting.WithStartsAt(d.StartsAt))
+	}
+	if d.Duration != 0 {
+		opts = append(opts, clickmeeting.WithDuration(d.Duration))
+	}
+	if !d.Settings.IsEmpty() {
+		opts = append(opts, clickmeeting.WithRoomSettingsPatch(d.Settings))
+	}
+	return newRoom, opts, nil
+}
@@ -0,0 +1,247 @@
+package spec_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IAmRadek/clickmeeting.go"
+	"github.com/IAmRadek/clickmeeting.go/spec"
+	"github.com/matryer/is"
+)
+
+// fakeClient keeps rooms in memory. Methods not used by spec panic through the nil Client.
+type fakeClient struct {
+	clickmeeting.Client
+
+	rooms   map[int]clickmeeting.Room
+	nextID  int
+	updates map[int]url.Values
+	created map[int]url.Values
+}
+
+func newFakeClient(rooms ...clickmeeting.Room) *fakeClient {
+	c := &fakeClient{rooms: map[int]clickmeeting.Room{}, nextID: 100, updates: map[int]url.Values{}, created: map[int]url.Values{}}
+	for _, room := range rooms {
+		c.rooms[room.ID] = room
+	}
+	return c
+}
+
+func (c *fakeClient) ListRooms(_ context.Context, status clickmeeting.RoomStatus) ([]clickmeeting.Room, error) {
+	var rooms []clickmeeting.Room
+	for _, room := range c.rooms {
+		if room.Status == status || (room.Status == "" && status == clickmeeting.ActiveRoom) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (c *fakeClient) CreateRoom(_ context.Context, newRoom clickmeeting.NewRoom, opts ...clickmeeting.CreateRoomOption) (clickmeeting.Room, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	c.nextID++
+	room := clickmeeting.Room{ID: c.nextID, Name: newRoom.Name, RoomType: newRoom.RoomType, AccessType: newRoom.AccessType}
+	c.rooms[room.ID] = room
+	c.created[room.ID] = v
+	return room, nil
+}
+
+func (c *fakeClient) UpdateRoom(_ context.Context, roomID int, opts ...clickmeeting.UpdateRoomOption) (clickmeeting.Room, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	c.updates[roomID] = v
+	return c.rooms[roomID], nil
+}
+
+func (c *fakeClient) DeleteRoom(_ context.Context, roomID int) error {
+	if _, ok := c.rooms[roomID]; !ok {
+		return clickmeeting.APIError{Code: 404}
+	}
+	delete(c.rooms, roomID)
+	return nil
+}
+
+const yamlSpec = `
+rooms:
+  - key: weekly
+    name: Weekly sync
+    type: meeting
+    access: password
+    password: secret
+    permanent: true
+    lobby:
+      enabled: true
+      description: We will start shortly
+    settings:
+      recorder_autostart_enabled: true
+  - key: webinar
+    name: Product webinar
+    type: webinar
+    schedule:
+      starts_at: 2021-10-11 10:00
+      timezone: UTC
+      duration: 1h30m
+`
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_Load(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		is := is.New(t)
+
+		rooms, err := spec.Load(writeFile(t, "rooms.yaml", yamlSpec))
+		is.NoErr(err)
+		is.Equal(len(rooms), 2)
+		is.Equal(rooms[0].Key, "weekly")
+		is.Equal(*rooms[0].Settings.RecorderAutostartEnabled, true)
+		is.Equal(rooms[1].Schedule.Duration, "1h30m")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		is := is.New(t)
+
+		rooms, err := spec.Load(writeFile(t, "rooms.json", `{"rooms": [{"key": "weekly", "name": "Weekly sync", "lobby": {"enabled": true}}]}`))
+		is.NoErr(err)
+		is.Equal(len(rooms), 1)
+		is.Equal(rooms[0].Lobby.Enabled, true)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		is := is.New(t)
+
+		for _, content := range []string{
+			"rooms:\n  - name: No key\n",
+			"rooms:\n  - key: a\n    name: A\n  - key: a\n    name: B\n",
+			"rooms:\n  - key: a\n    name: A\n    access: password\n",
+			"rooms:\n  - key: a\n    name: A\n    schedule:\n      duration: forever\n",
+			"rooms:\n  - key: a\n    name: A\n    unknown: field\n",
+		} {
+			_, err := spec.Load(writeFile(t, "rooms.yml", content))
+			is.True(err != nil) // invalid spec must be rejected
+		}
+	})
+}
+
+func Test_PlanApply(t *testing.T) {
+	is := is.New(t)
+
+	rooms, err := spec.Load(writeFile(t, "rooms.yaml", yamlSpec))
+	is.NoErr(err)
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	state, err := spec.LoadState(statePath)
+	is.NoErr(err)
+	state.Rooms["weekly"] = 1
+	state.Rooms["retired"] = 2
+
+	client := newFakeClient(
+		clickmeeting.Room{ID: 1, Name: "Weekly", RoomType: clickmeeting.Meeting, AccessType: clickmeeting.OpenType, PermanentRoom: true, LobbyEnabled: true, LobbyDescription: "We will start shortly"},
+		clickmeeting.Room{ID: 2, Name: "Retired", Status: clickmeeting.InactiveRoom},
+	)
+
+	plan, err := spec.MakePlan(context.Background(), client, rooms, state)
+	is.NoErr(err)
+	is.Equal(plan.String(), `~ update weekly (room 1)
+    name: "Weekly" -> "Weekly sync"
+    access_type: OpenType -> PasswordProtected
+    settings[recorder_autostart_enabled]: false -> true
++ create webinar ("Product webinar")
+- delete retired (room 2)
+Plan: 1 to create, 1 to update, 1 to delete.`)
+
+	is.NoErr(spec.Apply(context.Background(), client, plan, state))
+	is.NoErr(state.Save(statePath))
+
+	is.Equal(client.updates[1], url.Values{
+		"name":                                 {"Weekly sync"},
+		"access_type":                          {"2"},
+		"password":                             {"secret"},
+		"settings[recorder_autostart_enabled]": {"1"},
+	})
+	is.Equal(client.created[101].Get("starts_at"), "2021-10-11 10:00:00")
+	is.Equal(client.created[101].Get("duration"), "1:30")
+	_, ok := client.rooms[2]
+	is.True(!ok) // retired room deleted
+
+	state, err = spec.LoadState(statePath)
+	is.NoErr(err)
+	is.Equal(state.Rooms, map[string]int{"weekly": 1, "webinar": 101})
+}
+
+func Test_PlanWithoutState(t *testing.T) {
+	is := is.New(t)
+
+	rooms, err := spec.Load(writeFile(t, "rooms.yaml", yamlSpec))
+	is.NoErr(err)
+	client := newFakeClient()
+
+	plan, err := spec.MakePlan(context.Background(), client, rooms, nil)
+	is.NoErr(err)
+	is.Equal(len(plan.Changes), len(rooms))
+	for _, c := range plan.Changes {
+		is.Equal(c.Action, spec.Create)
+	}
+
+	is.True(spec.Apply(context.Background(), client, plan, nil) != nil)
+
+	var state spec.State
+	is.NoErr(spec.Apply(context.Background(), client, plan, &state))
+	is.Equal(len(state.Rooms), len(rooms))
+}
+
+func Test_PlanAdopt(t *testing.T) {
+	is := is.New(t)
+
+	rooms, err := spec.Load(writeFile(t, "rooms.yaml", `
+rooms:
+  - key: weekly
+    slug: weekly-sync
+    name: Weekly sync
+  - key: standup
+    slug: standup
+    name: Standup
+  - key: retro
+    slug: retro
+    name: Retro
+`))
+	is.NoErr(err)
+
+	client := newFakeClient(
+		clickmeeting.Room{ID: 1, Name: "Weekly", Slug: "weekly-sync", RoomType: clickmeeting.Meeting, AccessType: clickmeeting.OpenType},
+		clickmeeting.Room{ID: 2, Name: "Standup", Slug: "standup", RoomType: clickmeeting.Meeting, AccessType: clickmeeting.OpenType},
+		clickmeeting.Room{ID: 3, Name: "Retro", Slug: "retro", RoomType: clickmeeting.Meeting, AccessType: clickmeeting.OpenType},
+	)
+	state := &spec.State{Rooms: map[string]int{"old-retro": 3}}
+
+	plan, err := spec.MakePlan(context.Background(), client, rooms, state)
+	is.NoErr(err)
+	is.Equal(plan.String(), `~ adopt weekly (room 1)
+    name: "Weekly" -> "Weekly sync"
+~ adopt standup (room 2)
++ create retro ("Retro")
+- delete old-retro (room 3)
+Plan: 2 to adopt, 1 to create, 0 to update, 1 to delete.`) // room 3 is managed by another key
+
+	is.NoErr(spec.Apply(context.Background(), client, plan, state))
+	is.Equal(state.Rooms, map[string]int{"weekly": 1, "standup": 2, "retro": 101})
+	is.Equal(client.updates[1], url.Values{"name": {"Weekly sync"}})
+	_, updated := client.updates[2]
+	is.True(!updated) // adopted room without changes is not updated
+	is.Equal(client.created[101].Get("custom_room_url_name"), "retro")
+}